@@ -8,7 +8,7 @@ import (
 	"log"
 	"net/http"
 
-	// stores "bakend/src/almacenamiento"
+	stores "bakend/src/almacenamiento"
 	// comandos "bakend/src/comandos"
 
 	"github.com/gorilla/handlers"
@@ -89,6 +89,11 @@ func main() {
 	originsOk := handlers.AllowedOrigins([]string{"http://localhost:3000"}) // Permite solo React
 	methodsOk := handlers.AllowedMethods([]string{"GET", "POST", "OPTIONS"})
 
+	// Restaurar las particiones montadas antes de apagar el servidor
+	if err := stores.LoadMountState(); err != nil {
+		fmt.Println("Error restaurando los montajes:", err)
+	}
+
 	fmt.Println("Servidor corriendo en http://localhost:4000")
 	log.Fatal(http.ListenAndServe(":4000", handlers.CORS(headersOk, originsOk, methodsOk)(router)))
 }
@@ -0,0 +1,86 @@
+package stores
+
+import (
+	utils "bakend/src/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Archivo donde se guarda el estado de los montajes entre reinicios del servidor
+var MountStateFile string = "mount_state.json"
+
+// Particion montada dentro del archivo de estado
+type mountEntry struct {
+	Path string `json:"path"` // Path del disco
+	Name string `json:"name"` // Nombre de la particion
+}
+
+// Contenido del archivo de estado
+type mountState struct {
+	Mounts       map[string]mountEntry `json:"mounts"`       // id -> particion montada
+	Letters      map[string]string     `json:"letters"`      // path -> letra asignada
+	Correlatives map[string]int        `json:"correlatives"` // path -> ultimo correlativo
+	NextLetter   int                   `json:"next_letter"`  // indice de la siguiente letra
+}
+
+// SaveMountState guarda en el archivo de estado las particiones montadas y las letras asignadas
+func SaveMountState() error {
+	state := mountState{Mounts: make(map[string]mountEntry)}
+	for id, path := range MountedPartitions {
+		state.Mounts[id] = mountEntry{Path: path, Name: MountedNames[id]}
+	}
+	state.Letters, state.Correlatives, state.NextLetter = utils.ExportLetterState()
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(MountStateFile, data, 0644)
+}
+
+// LoadMountState reconstruye la tabla de montajes a partir del archivo de estado
+// Solo se restauran los ids que el MBR del disco sigue marcando como montados
+func LoadMountState() error {
+	data, err := os.ReadFile(MountStateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		// Si no existe el archivo no hay nada que restaurar
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var state mountState
+	err = json.Unmarshal(data, &state)
+	if err != nil {
+		return fmt.Errorf("archivo de montajes invalido: %w", err)
+	}
+
+	// Las letras se restauran completas para que los ids nuevos no choquen con los anteriores
+	utils.RestoreLetterState(state.Letters, state.Correlatives, state.NextLetter)
+
+	ClearMountedPartitions()
+	for id, entry := range state.Mounts {
+		MountedPartitions[id] = entry.Path
+		MountedNames[id] = entry.Name
+		if !mountStillValid(id, entry) {
+			fmt.Printf("Montaje %s descartado: el disco %s ya no lo tiene montado\n", id, entry.Path)
+			RemoveMountedPartition(id)
+		}
+	}
+
+	// Se guarda de nuevo para quitar los montajes descartados
+	return SaveMountState()
+}
+
+// Verifica contra el MBR (o la cadena de EBRs) que la particion siga existiendo y montada con el mismo id
+func mountStillValid(id string, entry mountEntry) bool {
+	_, partition, _, err := getMountedPartitionMBR(id)
+	if err != nil || partition.Part_status[0] != '1' {
+		return false
+	}
+	return entry.Name == "" || strings.EqualFold(partition.GetName(), entry.Name)
+}
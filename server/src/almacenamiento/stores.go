@@ -3,6 +3,7 @@ package stores
 import (
 	structures "bakend/src/estructuras"
 	"errors"
+	"strings"
 )
 
 // Carnet
@@ -11,14 +12,26 @@ const Carnet string = "27" // 202101927
 // Declaración de variables globales
 var (
 	MountedPartitions map[string]string = make(map[string]string)
+	MountedNames      map[string]string = make(map[string]string) // id -> nombre de la partición
 )
 
 // GetMountedPartition obtiene la partición montada con el id especificado
 func GetMountedPartition(id string) (*structures.PARTITION, string, error) {
+	_, partition, path, err := getMountedPartitionMBR(id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return partition, path, nil
+}
+
+// Obtiene el MBR y la partición montada con el id especificado
+// Si el id no esta en el MBR se busca la partición lógica por su nombre dentro de la extendida
+func getMountedPartitionMBR(id string) (*structures.MBR, *structures.PARTITION, string, error) {
 	// Obtener el path de la partición montada
 	path := MountedPartitions[id]
 	if path == "" {
-		return nil, "", errors.New("la partición no está montada")
+		return nil, nil, "", errors.New("la partición no está montada")
 	}
 
 	// Crear una instancia de MBR
@@ -27,16 +40,46 @@ func GetMountedPartition(id string) (*structures.PARTITION, string, error) {
 	// Deserializar la estructura MBR desde un archivo binario
 	err := mbr.DeserializeMBR(path)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, "", err
 	}
 
 	// Buscar la partición con el id especificado
 	partition, err := mbr.GetPartitionByID(id)
-	if partition == nil {
-		return nil, "", err
+	if partition != nil {
+		return &mbr, partition, path, nil
 	}
 
-	return partition, path, nil
+	// Las particiones lógicas no guardan el id, se buscan por el nombre con el que se montaron
+	extendida := mbr.GetExtendedPartition2()
+	if extendida == nil || MountedNames[id] == "" {
+		return nil, nil, "", err
+	}
+	cadena, err := structures.GetEBRChain(path, extendida.Part_start)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	logica := structures.GetLogicalByName(cadena, MountedNames[id])
+	if logica == nil || logica.Ebr_mount[0] != '1' {
+		return nil, nil, "", errors.New("partición no encontrada")
+	}
+
+	return &mbr, logica.ToPartition(id), path, nil
+}
+
+// IsPartitionMounted indica si la partición name del disco path tiene un id en la tabla de montajes
+// Una partición marcada como montada en el disco sin id quedo huerfana, por ejemplo al montarla antes
+// de un reinicio sin el archivo de estado, y se puede volver a montar
+func IsPartitionMounted(path string, name string) bool {
+	for id, mountedPath := range MountedPartitions {
+		if mountedPath != path {
+			continue
+		}
+		_, partition, _, err := getMountedPartitionMBR(id)
+		if err == nil && strings.EqualFold(partition.GetName(), name) {
+			return true
+		}
+	}
+	return false
 }
 
 //Para mostrar las particiones que el comando mounted
@@ -49,27 +92,11 @@ func GetPartitions() map[string]string {
 
 // GetMountedMBR obtiene el MBR de la partición montada con el id especificado
 func GetMountedPartitionRep(id string) (*structures.MBR, *structures.SuperBlock, string, error) {
-	// Obtener el path de la partición montada
-	path := MountedPartitions[id]
-	if path == "" {
-		return nil, nil, "", errors.New("la partición no está montada")
-	}
-
-	// Crear una instancia de MBR
-	var mbr structures.MBR
-
-	// Deserializar la estructura MBR desde un archivo binario
-	err := mbr.DeserializeMBR(path)
+	mbr, partition, path, err := getMountedPartitionMBR(id)
 	if err != nil {
 		return nil, nil, "", err
 	}
 
-	// Buscar la partición con el id especificado
-	partition, err := mbr.GetPartitionByID(id)
-	if partition == nil {
-		return nil, nil, "", err
-	}
-
 	// Crear una instancia de SuperBlock
 	var sb structures.SuperBlock
 
@@ -79,37 +106,28 @@ func GetMountedPartitionRep(id string) (*structures.MBR, *structures.SuperBlock,
 		return nil, nil, "", err
 	}
 
-	return &mbr, &sb, path, nil
+	return mbr, &sb, path, nil
+}
+
+// Quita el id de la lista de particiones montadas
+func RemoveMountedPartition(id string) {
+	delete(MountedPartitions, id)
+	delete(MountedNames, id)
 }
 
 // Esta funcion reinicia el map de los ids
 func ClearMountedPartitions() {
 	MountedPartitions = make(map[string]string) // Reinicia el map
+	MountedNames = make(map[string]string)
 }
 
 // GetMountedPartitionSuperblock obtiene el SuperBlock de la partición montada con el id especificado
 func GetMountedPartitionSuperblock(id string) (*structures.SuperBlock, *structures.PARTITION, string, error) {
-	// Obtener el path de la partición montada
-	path := MountedPartitions[id]
-	if path == "" {
-		return nil, nil, "", errors.New("la partición no está montada")
-	}
-
-	// Crear una instancia de MBR
-	var mbr structures.MBR
-
-	// Deserializar la estructura MBR desde un archivo binario
-	err := mbr.DeserializeMBR(path)
+	_, partition, path, err := getMountedPartitionMBR(id)
 	if err != nil {
 		return nil, nil, "", err
 	}
 
-	// Buscar la partición con el id especificado
-	partition, err := mbr.GetPartitionByID(id)
-	if partition == nil {
-		return nil, nil, "", err
-	}
-
 	// Crear una instancia de SuperBlock
 	var sb structures.SuperBlock
 
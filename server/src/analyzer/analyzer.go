@@ -84,6 +84,13 @@ func Analyzer(input string) ([]interface{}, []error) {
 			// 	// Si el comando no es reconocido, agregamos el error
 			// 	errors = append(errors, fmt.Errorf("debe logearse para utilizar el comando \"mount\": %s", tokens[0]))
 			// }
+		case "unmount":
+			// Llama a la función para el unmount
+			result, err := comandos.ParseUnmount(tokens[1:])
+			results = append(results, result)
+			if err != nil {
+				errors = append(errors, err)
+			}
 		case "mounted":
 			//if comandos.ObtenerLogin() {
 			// Llama a la función para el mounted
@@ -97,6 +104,34 @@ func Analyzer(input string) ([]interface{}, []error) {
 			// 	// Si el comando no es reconocido, agregamos el error
 			// 	errors = append(errors, fmt.Errorf("debe logearse para utilizar el comando \"mounted\": %s", tokens[0]))
 			// }
+		case "df":
+			// Llama a la función para mostrar el uso de las particiones montadas
+			result, err := comandos.ParseDf(tokens[1:])
+			results = append(results, result)
+			if err != nil {
+				errors = append(errors, err)
+			}
+		case "checkdisk":
+			// Llama a la función para validar el disco
+			result, err := comandos.ParseCheckdisk(tokens[1:])
+			results = append(results, result)
+			if err != nil {
+				errors = append(errors, err)
+			}
+		case "fsck":
+			// Llama a la función para validar el sistema de archivos
+			result, err := comandos.ParseFsck(tokens[1:])
+			results = append(results, result)
+			if err != nil {
+				errors = append(errors, err)
+			}
+		case "migrate":
+			// Llama a la función para actualizar el formato del sistema de archivos
+			result, err := comandos.ParseMigrate(tokens[1:])
+			results = append(results, result)
+			if err != nil {
+				errors = append(errors, err)
+			}
 		case "mkfs":
 			result, err := comandos.ParseMkfs(tokens[1:])
 			results = append(results, result)
@@ -104,6 +139,18 @@ func Analyzer(input string) ([]interface{}, []error) {
 			if err != nil {
 				errors = append(errors, err)
 			}
+		case "loss": //Este comando simula la perdida del sistema de archivos ext3
+			result, err := comandos.ParseLoss(tokens[1:])
+			results = append(results, result)
+			if err != nil {
+				errors = append(errors, err)
+			}
+		case "recovery": //Este comando recupera el sistema de archivos ext3 con el journal
+			result, err := comandos.ParseRecovery(tokens[1:])
+			results = append(results, result)
+			if err != nil {
+				errors = append(errors, err)
+			}
 		case "login":
 			if !comandos.ObtenerLogin() {
 				result, err := comandos.ParseLogin(tokens[1:])
@@ -218,6 +265,106 @@ func Analyzer(input string) ([]interface{}, []error) {
 				// Si el comando no es reconocido, agregamos el error
 				errors = append(errors, fmt.Errorf("debe logearse para utilizar el comando \"mkfile\": %s", tokens[0]))
 			}
+		case "remove": //Este comando elimina un archivo o una carpeta con su contenido
+			if comandos.ObtenerLogin() {
+				result, err := comandos.ParseRemove(tokens[1:])
+				results = append(results, result)
+				if err != nil {
+					errors = append(errors, err)
+				}
+			} else {
+				errors = append(errors, fmt.Errorf("debe logearse para utilizar el comando \"remove\": %s", tokens[0]))
+			}
+		case "edit": //Este comando reemplaza o agrega contenido a un archivo
+			if comandos.ObtenerLogin() {
+				result, err := comandos.ParseEdit(tokens[1:])
+				results = append(results, result)
+				if err != nil {
+					errors = append(errors, err)
+				}
+			} else {
+				errors = append(errors, fmt.Errorf("debe logearse para utilizar el comando \"edit\": %s", tokens[0]))
+			}
+		case "rename": //Este comando cambia el nombre de un archivo o carpeta
+			if comandos.ObtenerLogin() {
+				result, err := comandos.ParseRename(tokens[1:])
+				results = append(results, result)
+				if err != nil {
+					errors = append(errors, err)
+				}
+			} else {
+				errors = append(errors, fmt.Errorf("debe logearse para utilizar el comando \"rename\": %s", tokens[0]))
+			}
+		case "copy": //Este comando copia un archivo o carpeta a otra carpeta
+			if comandos.ObtenerLogin() {
+				result, err := comandos.ParseCopy(tokens[1:])
+				results = append(results, result)
+				if err != nil {
+					errors = append(errors, err)
+				}
+			} else {
+				errors = append(errors, fmt.Errorf("debe logearse para utilizar el comando \"copy\": %s", tokens[0]))
+			}
+		case "move": //Este comando mueve un archivo o carpeta a otra carpeta
+			if comandos.ObtenerLogin() {
+				result, err := comandos.ParseMove(tokens[1:])
+				results = append(results, result)
+				if err != nil {
+					errors = append(errors, err)
+				}
+			} else {
+				errors = append(errors, fmt.Errorf("debe logearse para utilizar el comando \"move\": %s", tokens[0]))
+			}
+		case "ln": //Este comando crea un enlace duro o simbolico
+			if comandos.ObtenerLogin() {
+				result, err := comandos.ParseLn(tokens[1:])
+				results = append(results, result)
+				if err != nil {
+					errors = append(errors, err)
+				}
+			} else {
+				errors = append(errors, fmt.Errorf("debe logearse para utilizar el comando \"ln\": %s", tokens[0]))
+			}
+		case "find": //Este comando busca archivos y carpetas por nombre
+			if comandos.ObtenerLogin() {
+				result, err := comandos.ParseFind(tokens[1:])
+				results = append(results, result)
+				if err != nil {
+					errors = append(errors, err)
+				}
+			} else {
+				errors = append(errors, fmt.Errorf("debe logearse para utilizar el comando \"find\": %s", tokens[0]))
+			}
+		case "du": //Este comando muestra los bloques que usa una carpeta con su contenido
+			if comandos.ObtenerLogin() {
+				result, err := comandos.ParseDu(tokens[1:])
+				results = append(results, result)
+				if err != nil {
+					errors = append(errors, err)
+				}
+			} else {
+				errors = append(errors, fmt.Errorf("debe logearse para utilizar el comando \"du\": %s", tokens[0]))
+			}
+		case "chmod": //Este comando cambia los permisos UGO de archivos y carpetas
+			if comandos.ObtenerLogin() {
+				result, err := comandos.ParseChmod(tokens[1:])
+				results = append(results, result)
+				if err != nil {
+					errors = append(errors, err)
+				}
+			} else {
+				errors = append(errors, fmt.Errorf("debe logearse para utilizar el comando \"chmod\": %s", tokens[0]))
+			}
+		case "chown": //Este comando cambia el propietario de archivos y carpetas
+			if comandos.ObtenerLogin() {
+				result, err := comandos.ParseChown(tokens[1:])
+				results = append(results, result)
+				if err != nil {
+					errors = append(errors, err)
+				}
+			} else {
+				errors = append(errors, fmt.Errorf("debe logearse para utilizar el comando \"chown\": %s", tokens[0]))
+			}
 		case "cat": //Este comando obyiene el texto del archivo
 			if comandos.ObtenerLogin() {
 				result, err := comandos.ParseCat(tokens[1:])
@@ -77,7 +77,7 @@ func commandFile(comando *CAT) error {
 	comando.textObte += "***************** CAT ********************"
 	for _, direccion := range comando.file {
 		//Aca se recorre y se obtiene
-		err2 := ObtnerFileDisco(comando, partitionSuperblock, partitionPath, direccion)
+		err2 := ObtnerFileDisco(comando, partitionSuperblock, partitionPath, direccion, usuario)
 		if err2 != nil {
 			return fmt.Errorf("error al intenter obtener el archivo .txt: %w", err2)
 		}
@@ -86,7 +86,8 @@ func commandFile(comando *CAT) error {
 	return nil
 }
 
-func ObtnerFileDisco(comando *CAT, superblock *structures.SuperBlock, diskPath string, path_file_ls string) error {
+// El archivo se lee con los permisos del usuario logeado
+func ObtnerFileDisco(comando *CAT, superblock *structures.SuperBlock, diskPath string, path_file_ls string, usuario *LOGIN) error {
 
 	// GetParentDirectories obtiene las carpetas padres y el directorio de destino
 	parentDirs, nombreArchivo := utils.GetParentDirectories(path_file_ls)
@@ -94,7 +95,7 @@ func ObtnerFileDisco(comando *CAT, superblock *structures.SuperBlock, diskPath s
 	// fmt.Println("Nombre archivo:", nombreArchivo)
 
 	//Aca ya se debe de obtner el archivo en el disco virtual con el -path
-	cade, err2 := superblock.GetFileContent(diskPath, parentDirs, nombreArchivo)
+	cade, err2 := superblock.GetFileContent(diskPath, parentDirs, nombreArchivo, usuario.uid, usuario.gid)
 	if err2 != nil {
 		return fmt.Errorf("error al obtener el archivo: %w", err2)
 	}
@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	structures "bakend/src/estructuras"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CHECKDISK estructura que representa el comando checkdisk con sus parámetros
+type CHECKDISK struct {
+	path   string // Ruta del archivo del disco
+	repair bool   // Aplicar las reparaciones seguras
+}
+
+/*
+	checkdisk -path=/home/Disco1.mia
+	checkdisk -path=/home/Disco1.mia -repair
+*/
+
+// ParseCheckdisk parsea el comando checkdisk y devuelve una instancia de CHECKDISK
+func ParseCheckdisk(tokens []string) (*CHECKDISK, error) {
+	cmd := &CHECKDISK{} // Crea una nueva instancia de CHECKDISK
+
+	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
+	args := strings.Join(tokens, " ")
+	// Expresión regular para encontrar los parámetros del comando checkdisk
+	re := regexp.MustCompile(`-(?i:path="[^"]+"|path=[^\s]+|repair)`)
+	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
+	matches := re.FindAllString(args, -1)
+
+	// Itera sobre cada coincidencia encontrada
+	for _, match := range matches {
+		// Divide cada parte en clave y valor usando "=" como delimitador
+		kv := strings.SplitN(match, "=", 2)
+		key := strings.ToLower(kv[0])
+
+		// Switch para manejar diferentes parámetros
+		switch key {
+		case "-path":
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
+			}
+			value := kv[1]
+			// Remove quotes from value if present
+			if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+				value = strings.Trim(value, "\"")
+			}
+			cmd.path = value
+		case "-repair":
+			cmd.repair = true
+		default:
+			// Si el parámetro no es reconocido, devuelve un error
+			return nil, fmt.Errorf("parámetro desconocido: %s", key)
+		}
+	}
+
+	// Verifica que el parámetro -path haya sido proporcionado
+	if cmd.path == "" {
+		return nil, errors.New("faltan parámetros requeridos: -path")
+	}
+
+	// Validamos el disco
+	resultado, err := commandCheckdisk(cmd)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return nil, err
+	}
+
+	return cmd, errors.New(resultado)
+}
+
+func commandCheckdisk(check *CHECKDISK) (string, error) {
+	issues, err := structures.CheckDisk(check.path, check.repair)
+	if err != nil {
+		return "", fmt.Errorf("error en el checkdisk: %w", err)
+	}
+
+	if len(issues) == 0 {
+		return fmt.Sprintf("checkdisk %s: no se encontraron problemas", check.path), nil
+	}
+
+	// Se muestra un problema por linea
+	resultado := fmt.Sprintf("checkdisk %s: %d problema(s) encontrados", check.path, len(issues))
+	for _, issue := range issues {
+		resultado += "\n" + issue.String()
+	}
+	return resultado, nil
+}
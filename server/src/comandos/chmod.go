@@ -0,0 +1,105 @@
+package analyzer
+
+import (
+	stores "bakend/src/almacenamiento"
+	utils "bakend/src/utils"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CHMOD estructura que representa el comando chmod con sus parámetros
+type CHMOD struct {
+	path string // Path del archivo o carpeta
+	ugo  string // Permisos del propietario, grupo y otros, ej. 764
+	r    bool   // Opción -r (cambia tambien el contenido de la carpeta)
+}
+
+/*
+   chmod -path=/home/user/docs/a.txt -ugo=764
+   chmod -r -path="/home/mis documentos" -ugo=777
+*/
+
+func ParseChmod(tokens []string) (*CHMOD, error) {
+	cmd := &CHMOD{} // Crea una nueva instancia de CHMOD
+
+	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
+	args := strings.Join(tokens, " ")
+	// Expresión regular para encontrar los parámetros del comando chmod
+	re := regexp.MustCompile(`-(?i:path="[^"]+"|path=[^\s]+|ugo=[^\s]+|r)`)
+	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
+	matches := re.FindAllString(args, -1)
+
+	// Itera sobre cada coincidencia encontrada
+	for _, match := range matches {
+		// Divide cada parte en clave y valor usando "=" como delimitador
+		kv := strings.SplitN(match, "=", 2)
+		key := strings.ToLower(kv[0])
+
+		// Switch para manejar diferentes parámetros
+		switch key {
+		case "-path", "-ugo":
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
+			}
+			value := kv[1]
+			// Remove comillas si estan present
+			if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+				value = strings.Trim(value, "\"")
+			}
+			if key == "-path" {
+				cmd.path = value
+			} else {
+				cmd.ugo = value
+			}
+		case "-r":
+			cmd.r = true
+		default:
+			// Si el parámetro no es reconocido, devuelve un error
+			return nil, fmt.Errorf("parámetro desconocido: %s", key)
+		}
+	}
+
+	// Verifica que los parámetros -path y -ugo hayan sido proporcionados
+	if cmd.path == "" {
+		return nil, errors.New("faltan parámetros requeridos: -path")
+	}
+	if cmd.ugo == "" {
+		return nil, errors.New("faltan parámetros requeridos: -ugo")
+	}
+	if len(cmd.ugo) != 3 {
+		return nil, errors.New("el parámetro -ugo debe de tener tres digitos, ej. 764")
+	}
+
+	err := commandChmod(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd, fmt.Errorf("permisos cambiados correctamente CHMOD: %+v", *cmd)
+}
+
+// Solo el root o el propietario pueden cambiar los permisos
+func commandChmod(chmod *CHMOD) error {
+	//Obtenemos el usuario logeado
+	var usuario = ObtenerUsuari()
+
+	// Obtener la partición montada
+	partitionSuperblock, _, partitionPath, err := stores.GetMountedPartitionSuperblock(usuario.id)
+	if err != nil {
+		return fmt.Errorf("error al obtener la partición montada: %w", err)
+	}
+
+	// GetParentDirectories obtiene las carpetas padres y el nombre del elemento
+	parentDirs, nombre := utils.GetParentDirectories(chmod.path)
+
+	perm := [3]byte{chmod.ugo[0], chmod.ugo[1], chmod.ugo[2]}
+	err = partitionSuperblock.ChmodPath(partitionPath, append(parentDirs, nombre), perm, chmod.r, usuario.uid, usuario.gid)
+	if err != nil {
+		return fmt.Errorf("error al cambiar los permisos: %w", err)
+	}
+
+	// Registrar la operación en el journal (solo en ext3)
+	return registrarJournal(partitionSuperblock, partitionPath, "chmod", chmod.path, journalFlag(chmod.ugo, chmod.r), usuario)
+}
@@ -0,0 +1,111 @@
+package analyzer
+
+import (
+	stores "bakend/src/almacenamiento"
+	utils "bakend/src/utils"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CHOWN estructura que representa el comando chown con sus parámetros
+type CHOWN struct {
+	path    string // Path del archivo o carpeta
+	usuario string // Nombre del nuevo propietario
+	r       bool   // Opción -r (cambia tambien el contenido de la carpeta)
+}
+
+/*
+   chown -path=/home/user/docs/a.txt -usuario=user2
+   chown -r -path="/home/mis documentos" -usuario=user2
+*/
+
+func ParseChown(tokens []string) (*CHOWN, error) {
+	cmd := &CHOWN{} // Crea una nueva instancia de CHOWN
+
+	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
+	args := strings.Join(tokens, " ")
+	// Expresión regular para encontrar los parámetros del comando chown
+	re := regexp.MustCompile(`-(?i:path="[^"]+"|path=[^\s]+|usuario="[^"]+"|usuario=[^\s]+|r)`)
+	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
+	matches := re.FindAllString(args, -1)
+
+	// Itera sobre cada coincidencia encontrada
+	for _, match := range matches {
+		// Divide cada parte en clave y valor usando "=" como delimitador
+		kv := strings.SplitN(match, "=", 2)
+		key := strings.ToLower(kv[0])
+
+		// Switch para manejar diferentes parámetros
+		switch key {
+		case "-path", "-usuario":
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
+			}
+			value := kv[1]
+			// Remove comillas si estan present
+			if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+				value = strings.Trim(value, "\"")
+			}
+			if key == "-path" {
+				cmd.path = value
+			} else {
+				cmd.usuario = value
+			}
+		case "-r":
+			cmd.r = true
+		default:
+			// Si el parámetro no es reconocido, devuelve un error
+			return nil, fmt.Errorf("parámetro desconocido: %s", key)
+		}
+	}
+
+	// Verifica que los parámetros -path y -usuario hayan sido proporcionados
+	if cmd.path == "" {
+		return nil, errors.New("faltan parámetros requeridos: -path")
+	}
+	if cmd.usuario == "" {
+		return nil, errors.New("faltan parámetros requeridos: -usuario")
+	}
+
+	err := commandChown(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd, fmt.Errorf("propietario cambiado correctamente CHOWN: %+v", *cmd)
+}
+
+// Solo el root o el propietario actual pueden cambiar el propietario
+func commandChown(chown *CHOWN) error {
+	//Obtenemos el usuario logeado
+	var usuario = ObtenerUsuari()
+
+	// Obtener la partición montada
+	partitionSuperblock, _, partitionPath, err := stores.GetMountedPartitionSuperblock(usuario.id)
+	if err != nil {
+		return fmt.Errorf("error al obtener la partición montada: %w", err)
+	}
+
+	// El nuevo propietario debe de existir en users.txt, el inodo 1 es users.txt
+	_, lines, err := leerUsersTxt(partitionPath, 1, partitionSuperblock)
+	if err != nil {
+		return fmt.Errorf("error al leer users.txt: %w", err)
+	}
+	uid, gid, err := buscarUsuario(lines, chown.usuario)
+	if err != nil {
+		return err
+	}
+
+	// GetParentDirectories obtiene las carpetas padres y el nombre del elemento
+	parentDirs, nombre := utils.GetParentDirectories(chown.path)
+
+	err = partitionSuperblock.ChownPath(partitionPath, append(parentDirs, nombre), uid, gid, chown.r, usuario.uid, usuario.gid)
+	if err != nil {
+		return fmt.Errorf("error al cambiar el propietario: %w", err)
+	}
+
+	// Registrar la operación en el journal (solo en ext3)
+	return registrarJournal(partitionSuperblock, partitionPath, "chown", chown.path, journalFlag(chown.usuario, chown.r), usuario)
+}
@@ -0,0 +1,104 @@
+package analyzer
+
+import (
+	stores "bakend/src/almacenamiento"
+	utils "bakend/src/utils"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// COPY estructura que representa el comando copy con sus parámetros
+type COPY struct {
+	path    string // Path del archivo o carpeta a copiar
+	destino string // Carpeta donde se coloca la copia
+}
+
+/*
+   copy -path=/home/user/docs/a.txt -destino=/home/images
+   copy -path="/home/mis documentos" -destino=/respaldo
+*/
+
+func ParseCopy(tokens []string) (*COPY, error) {
+	cmd := &COPY{} // Crea una nueva instancia de COPY
+
+	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
+	args := strings.Join(tokens, " ")
+	// Expresión regular para encontrar los parámetros del comando copy
+	re := regexp.MustCompile(`-(?i:path="[^"]+"|path=[^\s]+|destino="[^"]+"|destino=[^\s]+)`)
+	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
+	matches := re.FindAllString(args, -1)
+
+	// Itera sobre cada coincidencia encontrada
+	for _, match := range matches {
+		// Divide cada parte en clave y valor usando "=" como delimitador
+		kv := strings.SplitN(match, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+
+		// Remove comillas si estan present
+		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+			value = strings.Trim(value, "\"")
+		}
+
+		// Switch para manejar diferentes parámetros
+		switch key {
+		case "-path":
+			cmd.path = value
+		case "-destino":
+			cmd.destino = value
+		default:
+			// Si el parámetro no es reconocido, devuelve un error
+			return nil, fmt.Errorf("parámetro desconocido: %s", key)
+		}
+	}
+
+	// Verifica que los parámetros -path y -destino hayan sido proporcionados
+	if cmd.path == "" {
+		return nil, errors.New("faltan parámetros requeridos: -path")
+	}
+	if cmd.destino == "" {
+		return nil, errors.New("faltan parámetros requeridos: -destino")
+	}
+
+	err := commandCopy(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd, fmt.Errorf("copiado correctamente COPY: %+v", *cmd)
+}
+
+// Se copia con los permisos del usuario logeado
+func commandCopy(copia *COPY) error {
+	//Obtenemos el usuario logeado
+	var usuario = ObtenerUsuari()
+
+	// Obtener la partición montada
+	partitionSuperblock, mountedPartition, partitionPath, err := stores.GetMountedPartitionSuperblock(usuario.id)
+	if err != nil {
+		return fmt.Errorf("error al obtener la partición montada: %w", err)
+	}
+
+	// GetParentDirectories obtiene las carpetas padres y el nombre a copiar
+	parentDirs, nombre := utils.GetParentDirectories(copia.path)
+	// La carpeta destino completa
+	destDirs, destDir := utils.GetParentDirectories(copia.destino)
+
+	err = partitionSuperblock.CopyPath(partitionPath, parentDirs, nombre, append(destDirs, destDir), usuario.uid, usuario.gid)
+	if err != nil {
+		return fmt.Errorf("error al copiar: %w", err)
+	}
+
+	// Serializar el superbloque con los inodos y bloques reservados
+	err = partitionSuperblock.Serialize(partitionPath, int64(mountedPartition.Part_start))
+	if err != nil {
+		return fmt.Errorf("error al serializar el superbloque: %w", err)
+	}
+
+	// Registrar la operación en el journal (solo en ext3)
+	return registrarJournal(partitionSuperblock, partitionPath, "copy", copia.path, copia.destino, usuario)
+}
@@ -0,0 +1,69 @@
+package analyzer
+
+import (
+	stores "bakend/src/almacenamiento"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DF estructura que representa el comando df, no tiene parámetros
+type DF struct {
+	textObte string
+}
+
+/*
+	df
+*/
+
+// ParseDf muestra el uso de inodos y bloques de todas las particiones montadas
+func ParseDf(tokens []string) (*DF, error) {
+	cmd := &DF{} // Crea una nueva instancia de DF
+
+	if len(tokens) > 0 {
+		return nil, fmt.Errorf("parámetro desconocido: %s", tokens[0])
+	}
+
+	commandDf(cmd)
+
+	return cmd, fmt.Errorf("%s", cmd.textObte)
+}
+
+// Los valores se toman del superbloque de cada partición montada, en orden de id
+func commandDf(df *DF) {
+	ids := make([]string, 0, len(stores.GetPartitions()))
+	for id := range stores.GetPartitions() {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	var texto strings.Builder
+	texto.WriteString("***************** DF ********************\n")
+	texto.WriteString(fmt.Sprintf("%-8s %8s %8s %8s %6s %8s %8s %8s %6s", "ID", "INODOS", "USADOS", "LIBRES", "USO%", "BLOQUES", "USADOS", "LIBRES", "USO%"))
+
+	for _, id := range ids {
+		sb, _, _, err := stores.GetMountedPartitionSuperblock(id)
+		if err != nil {
+			texto.WriteString(fmt.Sprintf("\n%-8s %v", id, err))
+			continue
+		}
+		if sb.S_magic != 0xEF53 {
+			texto.WriteString(fmt.Sprintf("\n%-8s sin sistema de archivos", id))
+			continue
+		}
+
+		texto.WriteString(fmt.Sprintf("\n%-8s %8d %8d %8d %6s %8d %8d %8d %6s", id,
+			sb.TotalInodes(), sb.S_inodes_count, sb.S_free_inodes_count, porcentajeUso(sb.S_inodes_count, sb.TotalInodes()),
+			sb.TotalBlocks(), sb.S_blocks_count, sb.S_free_blocks_count, porcentajeUso(sb.S_blocks_count, sb.TotalBlocks())))
+	}
+
+	df.textObte = texto.String()
+}
+
+// Porcentaje de usados sobre total con un decimal
+func porcentajeUso(usados int32, total int32) string {
+	if total <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(usados)*100/float64(total))
+}
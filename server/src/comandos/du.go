@@ -0,0 +1,103 @@
+package analyzer
+
+import (
+	stores "bakend/src/almacenamiento"
+	utils "bakend/src/utils"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DU estructura que representa el comando du con sus parámetros
+type DU struct {
+	path     string // Carpeta o archivo que se mide
+	s        bool   // Opción -s (solo el total de la ruta)
+	textObte string
+}
+
+/*
+   du -path=/home
+   du -s -path="/home/mis documentos"
+*/
+
+func ParseDu(tokens []string) (*DU, error) {
+	cmd := &DU{} // Crea una nueva instancia de DU
+
+	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
+	args := strings.Join(tokens, " ")
+	// Expresión regular para encontrar los parámetros del comando du
+	re := regexp.MustCompile(`-(?i:path="[^"]+"|path=[^\s]+|s)`)
+	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
+	matches := re.FindAllString(args, -1)
+
+	// Itera sobre cada coincidencia encontrada
+	for _, match := range matches {
+		// Divide cada parte en clave y valor usando "=" como delimitador
+		kv := strings.SplitN(match, "=", 2)
+		key := strings.ToLower(kv[0])
+
+		// Switch para manejar diferentes parámetros
+		switch key {
+		case "-path":
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
+			}
+			value := kv[1]
+			// Remove comillas si estan present
+			if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+				value = strings.Trim(value, "\"")
+			}
+			cmd.path = value
+		case "-s":
+			cmd.s = true
+		default:
+			// Si el parámetro no es reconocido, devuelve un error
+			return nil, fmt.Errorf("parámetro desconocido: %s", key)
+		}
+	}
+
+	// Verifica que el parámetro -path haya sido proporcionado
+	if cmd.path == "" {
+		return nil, errors.New("faltan parámetros requeridos: -path")
+	}
+
+	err := commandDu(cmd)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return nil, err
+	}
+
+	return cmd, fmt.Errorf("%s", cmd.textObte)
+}
+
+// Se recorre con los permisos del usuario logeado
+func commandDu(du *DU) error {
+	//Obtenemos el usuario logeado
+	var usuario = ObtenerUsuari()
+
+	// Obtener la partición montada
+	partitionSuperblock, _, partitionPath, err := stores.GetMountedPartitionSuperblock(usuario.id)
+	if err != nil {
+		return fmt.Errorf("error al obtener la partición montada: %w", err)
+	}
+
+	// La ruta completa que se mide
+	parentDirs, destDir := utils.GetParentDirectories(du.path)
+
+	usos, err := partitionSuperblock.DiskUsagePath(partitionPath, append(parentDirs, destDir), du.s, usuario.uid, usuario.gid)
+	if err != nil {
+		return fmt.Errorf("error en el du: %w", err)
+	}
+
+	var texto strings.Builder
+	texto.WriteString("***************** DU ********************\n")
+	texto.WriteString(fmt.Sprintf("%8s %10s  %s", "BLOQUES", "BYTES", "RUTA"))
+	for _, uso := range usos {
+		bytes := int64(uso.Bloques) * int64(partitionSuperblock.S_block_size)
+		texto.WriteString(fmt.Sprintf("\n%8d %10d  %s", uso.Bloques, bytes, uso.Ruta))
+	}
+
+	du.textObte = texto.String()
+	return nil
+}
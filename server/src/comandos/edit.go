@@ -0,0 +1,118 @@
+package analyzer
+
+import (
+	stores "bakend/src/almacenamiento"
+	utils "bakend/src/utils"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// EDIT estructura que representa el comando edit con sus parámetros
+type EDIT struct {
+	path      string // Path del archivo en la partición
+	contenido string // Path del archivo en la computadora con el nuevo contenido
+	append    bool   // Agrega el contenido al final en lugar de reemplazarlo
+}
+
+/*
+   edit -path=/home/user/docs/a.txt -contenido=/home/ubuntu/nuevo.txt
+   edit -path=/home/user/docs/a.txt -contenido="/home/mis documentos/extra.txt" -append
+*/
+
+func ParseEdit(tokens []string) (*EDIT, error) {
+	cmd := &EDIT{} // Crea una nueva instancia de EDIT
+
+	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
+	args := strings.Join(tokens, " ")
+	// Expresión regular para encontrar los parámetros del comando edit
+	re := regexp.MustCompile(`-(?i:path="[^"]+"|path=[^\s]+|contenido="[^"]+"|contenido=[^\s]+|append)`)
+	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
+	matches := re.FindAllString(args, -1)
+
+	// Itera sobre cada coincidencia encontrada
+	for _, match := range matches {
+		// Divide cada parte en clave y valor usando "=" como delimitador
+		kv := strings.SplitN(match, "=", 2)
+		key := strings.ToLower(kv[0])
+
+		// Switch para manejar diferentes parámetros
+		switch key {
+		case "-path", "-contenido":
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
+			}
+			value := kv[1]
+			// Remove quotes from value if present
+			if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+				value = strings.Trim(value, "\"")
+			}
+			if key == "-path" {
+				cmd.path = value
+			} else {
+				cmd.contenido = value
+			}
+		case "-append":
+			cmd.append = true
+		default:
+			// Si el parámetro no es reconocido, devuelve un error
+			return nil, fmt.Errorf("parámetro desconocido: %s", key)
+		}
+	}
+
+	// Verifica que los parámetros -path y -contenido hayan sido proporcionados
+	if cmd.path == "" {
+		return nil, errors.New("faltan parámetros requeridos: -path")
+	}
+	if cmd.contenido == "" {
+		return nil, errors.New("faltan parámetros requeridos: -contenido")
+	}
+
+	err := commandEdit(cmd)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return nil, err
+	}
+
+	return cmd, fmt.Errorf("archivo editado exitosamente: %+v", *cmd)
+}
+
+// Se edita con los permisos del usuario logeado
+func commandEdit(edit *EDIT) error {
+	//Obtenemos el usuario logeado
+	var usuario = ObtenerUsuari()
+
+	// Obtener la partición montada
+	partitionSuperblock, mountedPartition, partitionPath, err := stores.GetMountedPartitionSuperblock(usuario.id)
+	if err != nil {
+		return fmt.Errorf("error al obtener la partición montada: %w", err)
+	}
+
+	// Se lee el nuevo contenido desde el archivo de la computadora
+	contenido, err := LeerArchivo(edit.contenido)
+	if err != nil {
+		return fmt.Errorf("error al leer el archivo: %w", err)
+	}
+
+	// GetParentDirectories obtiene las carpetas padres y el nombre del archivo
+	parentDirs, nombreArchivo := utils.GetParentDirectories(edit.path)
+
+	err = partitionSuperblock.EditFile(partitionPath, parentDirs, nombreArchivo, contenido, edit.append, usuario.uid, usuario.gid)
+	if err != nil {
+		return fmt.Errorf("error al editar el archivo: %w", err)
+	}
+
+	// Serializar el superbloque con los bloques reservados o liberados
+	err = partitionSuperblock.Serialize(partitionPath, int64(mountedPartition.Part_start))
+	if err != nil {
+		return fmt.Errorf("error al serializar el superbloque: %w", err)
+	}
+
+	// Registrar la operación en el journal (solo en ext3), append agrega al final del contenido
+	operacion := "edit"
+	if edit.append {
+		operacion = "append"
+	}
+	return registrarJournal(partitionSuperblock, partitionPath, operacion, edit.path, contenido, usuario)
+}
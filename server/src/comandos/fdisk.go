@@ -1,11 +1,12 @@
 package analyzer
 
 import (
-	"errors"  // Paquete para manejar errores y crear nuevos errores con mensajes personalizados
-	"fmt"     // Paquete para formatear cadenas y realizar operaciones de entrada/salida
-	"regexp"  // Paquete para trabajar con expresiones regulares, útil para encontrar y manipular patrones en cadenas
-	"strconv" // Paquete para convertir cadenas a otros tipos de datos, como enteros
-	"strings" // Paquete para manipular cadenas, como unir, dividir, y modificar contenido de cadenas
+	"encoding/binary" // Paquete para obtener el tamaño de las estructuras binarias
+	"errors"          // Paquete para manejar errores y crear nuevos errores con mensajes personalizados
+	"fmt"             // Paquete para formatear cadenas y realizar operaciones de entrada/salida
+	"regexp"          // Paquete para trabajar con expresiones regulares, útil para encontrar y manipular patrones en cadenas
+	"strconv"         // Paquete para convertir cadenas a otros tipos de datos, como enteros
+	"strings"         // Paquete para manipular cadenas, como unir, dividir, y modificar contenido de cadenas
 
 	structures "bakend/src/estructuras"
 	utils "bakend/src/utils"
@@ -19,12 +20,16 @@ type FDISK struct {
 	path string // Ruta del archivo del disco
 	typ  string // Tipo de partición (P, E, L)
 	name string // Nombre de la partición
+	del  string // Tipo de eliminación (fast, full)
+	add  int    // Espacio a agregar (positivo) o quitar (negativo) de la partición
 }
 
 /*
 	fdisk -size=1 -type=L -unit=M -fit=BF -name="Particion3" -path="/home/keviin/University/PRACTICAS/MIA_LAB_S2_2024/CLASEEXTRA/disks/Disco1.mia"
 	fdisk -size=300 -path=/home/Disco1.mia -name=Particion1
 	fdisk -type=E -path=/home/Disco2.mia -Unit=K -name=Particion2 -size=300
+	fdisk -delete=full -name="Particion1" -path=/home/Disco1.mia
+	fdisk -add=-500 -unit=K -path=/home/Disco1.mia -name=Particion1
 */
 
 // CommandFdisk parsea el comando fdisk y devuelve una instancia de FDISK
@@ -34,7 +39,7 @@ func ParseFdisk(tokens []string) (*FDISK, error) {
 	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
 	args := strings.Join(tokens, " ")
 	// Expresión regular para encontrar los parámetros del comando fdisk
-	re := regexp.MustCompile(`-(?i:size=\d+|unit=[kKmMbB]|fit=[bBfFwW]{2}|path="[^"]+"|path=[^\s]+|type=[pPeElL]|name="[^"]+"|name=[^\s]+)`)
+	re := regexp.MustCompile(`-(?i:size=\d+|unit=[kKmMbB]|fit=[bBfFwW]{2}|path="[^"]+"|path=[^\s]+|type=[pPeElL]|name="[^"]+"|name=[^\s]+|delete=[^\s]+|add=-?\d+)`)
 	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
 	matches := re.FindAllString(args, -1)
 
@@ -94,12 +99,67 @@ func ParseFdisk(tokens []string) (*FDISK, error) {
 				return nil, errors.New("el nombre no puede estar vacío")
 			}
 			cmd.name = value
+		case "-delete":
+			// Verifica que el tipo de eliminación sea "fast" o "full"
+			value = strings.ToLower(value)
+			if value != "fast" && value != "full" {
+				return nil, errors.New("el delete debe ser fast o full")
+			}
+			cmd.del = value
+		case "-add":
+			// Convierte el valor a agregar a un entero, puede ser negativo
+			add, err := strconv.Atoi(value)
+			if err != nil || add == 0 {
+				return nil, errors.New("el add debe ser un número entero distinto de cero")
+			}
+			cmd.add = add
 		default:
 			// Si el parámetro no es reconocido, devuelve un error
 			return nil, fmt.Errorf("parámetro desconocido: %s", key)
 		}
 	}
 
+	// Si se indicó -delete, unicamente se necesitan el -path y el -name
+	if cmd.del != "" {
+		if cmd.path == "" {
+			return nil, errors.New("faltan parámetros requeridos: -path")
+		}
+		if cmd.name == "" {
+			return nil, errors.New("faltan parámetros requeridos: -name")
+		}
+
+		// Eliminar la partición con los parámetros proporcionados
+		err := commandFdiskDelete(cmd)
+		if err != nil {
+			fmt.Println("Error:", err)
+			return cmd, err
+		}
+
+		return cmd, fmt.Errorf("particion eliminada: %+v", *cmd)
+	}
+
+	// Si se indicó -add, se modifica el tamaño de una partición existente
+	if cmd.add != 0 {
+		if cmd.path == "" {
+			return nil, errors.New("faltan parámetros requeridos: -path")
+		}
+		if cmd.name == "" {
+			return nil, errors.New("faltan parámetros requeridos: -name")
+		}
+		if cmd.unit == "" {
+			cmd.unit = "K"
+		}
+
+		// Modificar el tamaño de la partición
+		err := commandFdiskAdd(cmd)
+		if err != nil {
+			fmt.Println("Error:", err)
+			return cmd, err
+		}
+
+		return cmd, fmt.Errorf("tamaño de la particion modificado: %+v", *cmd)
+	}
+
 	// Verifica que los parámetros -size, -path y -name hayan sido proporcionados
 	if cmd.size == 0 {
 		return nil, errors.New("faltan parámetros requeridos: -size")
@@ -207,13 +267,19 @@ func createPrimaryPartition(fdisk *FDISK, sizeBytes int) error {
 		return errors.New("la particion es mas grande que el disco")
 	}
 
-	availablePartition, startPartition, indexPartition := mbr.GetFirstAvailablePartition() //*PARTITION, int, int   (Retornos)
+	availablePartition, startPartition, indexPartition := mbr.GetFirstAvailablePartition(sizeBytes) //*PARTITION, int, int   (Retornos)
 	if availablePartition == nil {
 		return errors.New("no hay particiones disponibles o la particion es mas grande que el disco")
 	}
+	if startPartition == -1 {
+		return errors.New("no existe un espacio libre en el disco donde quepa la particion")
+	}
+	if !mbr.ExisteNombre(fdisk.name) || existeNombreLogica(fdisk.path, &mbr, fdisk.name) {
+		return fmt.Errorf("ya existe una particion con el nombre: %s", fdisk.name)
+	}
 
 	//Se comprueba si existe otra particion con el mismo nombre
-	if mbr.ExisteNombre(fdisk.name) && startPartition != -1 {
+	if startPartition != -1 {
 		var corre = mbr.GetCorrelativo()
 
 		// Crear la partición con los parámetros proporcionados
@@ -258,7 +324,7 @@ func createExtendidaPartition(fdisk *FDISK, sizeBytes int) error {
 		return errors.New("la particion es mas grande que el disco")
 	}
 
-	availablePartition, startPartition, indexPartition := mbr.GetFirstAvailablePartition() //*PARTITION, int, int   (Retornos)
+	availablePartition, startPartition, indexPartition := mbr.GetFirstAvailablePartition(sizeBytes) //*PARTITION, int, int   (Retornos)
 	var err2 error
 	err2 = nil
 	if availablePartition == nil {
@@ -268,11 +334,17 @@ func createExtendidaPartition(fdisk *FDISK, sizeBytes int) error {
 	//Validar si existe otra paricion extendida(Solo puede aver una)
 	validacion_extendida := mbr.GetExtendedPartition()
 	if validacion_extendida {
-		err2 = errors.New("solo puede existir una partricion extendida dentro del disco")
+		return errors.New("solo puede existir una partricion extendida dentro del disco")
+	}
+	if startPartition == -1 {
+		return errors.New("no existe un espacio libre en el disco donde quepa la particion")
+	}
+	if !mbr.ExisteNombre(fdisk.name) || existeNombreLogica(fdisk.path, &mbr, fdisk.name) {
+		return fmt.Errorf("ya existe una particion con el nombre: %s", fdisk.name)
 	}
 
 	// Se comprueba si existe otra particion con el mismo nombre
-	if mbr.ExisteNombre(fdisk.name) && startPartition != -1 && !validacion_extendida {
+	if startPartition != -1 && !validacion_extendida {
 		//OBtiene el correlativo
 		var corre = mbr.GetCorrelativo()
 
@@ -333,18 +405,12 @@ func createEBR(fdisk *FDISK, sizeBytes int) error {
 
 // -------------------------------------------------------------Particion Logica--------------------------------------------------------------
 //  1. Obtenermos la particion extendida
-//  2. Obtenemos el start de la extendida
-//  3. Deserealizamos el EBR con el ofset del start
-//     Funcion Recursuiva:
-//  4. Comprobamos que la propiedad NEXT del EBR sea -1 (se agrega la informacion del EBR logico)
-//  5. SI NEXT != -1 se accede al sigueinte EBR y se vuelve a evaluar
+//  2. Obtenemos todos los EBR de la extendida
+//  3. Se busca el espacio libre segun el ajuste de la extendida (incluye los huecos de logicas eliminadas)
+//  4. Se agrega el nuevo EBR a la cadena manteniendo el orden dentro del disco
 func createLogicPartition(fdisk *FDISK, sizeBytes int) error {
 	// Crear una instancia de MBR
 	var mbr structures.MBR
-	var ebr structures.EBR
-	var posicion int32
-	var next_ebr int32
-	var particion *structures.PARTITION
 
 	// Deserializar la estructura MBR desde un archivo binario
 	err := mbr.DeserializeMBR(fdisk.path)
@@ -353,96 +419,70 @@ func createLogicPartition(fdisk *FDISK, sizeBytes int) error {
 		return err
 	}
 
-	// Imprimir MBR
-	//fmt.Println("\n--MBR original--")
-	//mbr.PrintMBR()
 	if int32(sizeBytes) >= mbr.Mbr_size {
 		return errors.New("la particion es mas grande que el disco")
 	}
 
 	//Validar si existe la particion extendida
-	var err2 error
-	err2 = nil
-	validacion_extendida := mbr.GetExtendedPartition() //Retonra true si existe la particion extendida
-	if validacion_extendida {
-		//obtenemos la particion extendida
-		particion = mbr.GetExtendedPartition2()
+	particion := mbr.GetExtendedPartition2() //Retonra nil si no existe la particion extendida
+	if particion == nil {
+		return errors.New("no existe una particion extendida donde crear la particion logica")
+	}
 
-		//Obtenemos el objeto EBR deserealizandolo del dico                                     //Objeto donde se deserealizara el ebr
-		err2 = ebr.DeserializeEBR(fdisk.path, particion.Part_start) //Se deserealiza el primer EBR de la extendida
-		if err2 != nil {
-			//fmt.Println("Error deserializando el EBR:", err2)
-			return err2
-		}
+	//Se comprueba que el nombre no exista en el disco
+	if !mbr.ExisteNombre(fdisk.name) || existeNombreLogica(fdisk.path, &mbr, fdisk.name) {
+		return errors.New("no puede existir dos particiones con el mismo nombre")
+	}
 
-		//Imprimimos el EBR para verificar
-		//fmt.Println("\n--EBR original (todos los ebr)--")
-		//ebr.PrintParticiones(fdisk.path)
+	//Obtenemos todos los EBR de la extendida
+	cadena, err := structures.GetEBRChain(fdisk.path, particion.Part_start)
+	if err != nil {
+		return err
+	}
 
-		//Necesito debolver la primera particion logica disponible
-		availablePartition, startPartition := ebr.GetFirstAvailablePartition(fdisk.path, fdisk.name) //*EBR, int   (Retornos)
-		if startPartition == -2 {                                                                    //Se comprueba si el nombre ya existe
-			err2 = errors.New("no puede existir dos particiones logicas con el mismo nombre")
-			return err2
-		}
-		if availablePartition == nil { //Se comprueba si exite alguna particion logica dispoble, next == -1
-			err2 = errors.New("ya no hay particiones logicas disponibles")
-			return err2
-		}
+	//Se obtiene la posicion del nuevo EBR segun el ajuste de la extendida
+	posicion := structures.GetFirstAvailableLogical(cadena, particion, int32(sizeBytes))
+	if posicion == -1 {
+		return errors.New("ya no hay espacio en la particion Extendida para agregar la particion logica")
+	}
 
-		// Seleccionar el tipo de ajuste
-		var fitByte byte
-		switch fdisk.fit {
-		case "FF":
-			fitByte = 'F'
-		case "BF":
-			fitByte = 'B'
-		case "WF":
-			fitByte = 'W'
-		default:
-			fmt.Println("Invalid fit type")
-			return nil
-		}
-		// Crear la partición con los parámetros proporcionados
-		availablePartition.CreatePartition([1]byte{fitByte}, int32(startPartition), int32(sizeBytes), fdisk.name)
+	// Seleccionar el tipo de ajuste
+	var fitByte byte
+	switch fdisk.fit {
+	case "FF":
+		fitByte = 'F'
+	case "BF":
+		fitByte = 'B'
+	case "WF":
+		fitByte = 'W'
+	default:
+		return errors.New("ajuste invalido")
+	}
 
-		//Comprovamos que aun hay espacio para agregar la particion logica dentro de la Extendida
-		if (availablePartition.Ebr_next + 30) > particion.Part_size {
-			err = errors.New("ya no hay espacio en la particion Extendida para agregar el nuevo EBR")
-			return err
-		}
+	// Crear la partición con los parámetros proporcionados, los datos inician despues del EBR
+	nuevo := structures.EBR{Ebr_mount: [1]byte{'N'}}
+	nuevo.CreatePartition([1]byte{fitByte}, posicion+int32(binary.Size(nuevo)), int32(sizeBytes), fdisk.name)
 
-		// Se cambio el puntero de las particiones a las actuales
-		//Se vuelve a obtener el nuevo ebr
-		err2 = ebr.DeserializeEBR(fdisk.path, availablePartition.Ebr_start)
-		if err2 != nil {
-			//fmt.Println("Error deserializando el EBR:", err2)
-			return err2
-		}
-		//Se asigna el ebr anterior al nuevo ebr
-		ebr = *availablePartition
+	//Se agrega a la cadena y se reescriben los enlaces
+	return structures.InsertLogicalPartition(fdisk.path, cadena, nuevo)
+}
 
-		// Imprimir las particiones del MBR solo para comprobar
-		posicion = availablePartition.Ebr_start
-		next_ebr = availablePartition.Ebr_next
+// Revisa si el nombre ya esta en uso por alguna particion logica del disco
+func existeNombreLogica(path string, mbr *structures.MBR, name string) bool {
+	extendida := mbr.GetExtendedPartition2()
+	if extendida == nil {
+		return false
 	}
-
-	// Serializar el MBR en el archivo binario
-	err = ebr.SerializeEBR(fdisk.path, posicion)
+	cadena, err := structures.GetEBRChain(path, extendida.Part_start)
 	if err != nil {
-		fmt.Println("Error:", err)
-		return err //En caso de que ocura un error se retorna
+		return false
 	}
-
-	//Creamos el sigueinte EBR
-	err = createEBR_siguiente(fdisk, next_ebr)
-	if err != nil {
-		fmt.Println("Error:", err)
-		return err //En caso de que ocura un error se retorna
+	for _, ebr := range cadena {
+		if !ebr.IsEmpty() && strings.EqualFold(ebr.GetName(), name) {
+			return true
+		}
 	}
-
-	return err2 //Si no ocurrio ningun error se retorna nil
-	//Falta crear el nuevo EBR con apuntadores nulos
+	return false
 }
 
 // Se crea el EBR dentro de la particion
@@ -466,3 +506,306 @@ func createEBR_siguiente(fdisk *FDISK, posicion int32) error {
 
 	return nil
 }
+
+// -------------------------------------------------------------Eliminar Particion--------------------------------------------------------------
+//  1. Se busca la particion por nombre dentro del MBR (primaria o extendida)
+//  2. Si no existe en el MBR se busca dentro de la cadena de EBRs (logica)
+//  3. Con -delete=full ademas se llena de ceros el espacio que ocupaba
+func commandFdiskDelete(fdisk *FDISK) error {
+	// Crear una instancia de MBR
+	var mbr structures.MBR
+
+	// Deserializar la estructura MBR desde un archivo binario
+	err := mbr.DeserializeMBR(fdisk.path)
+	if err != nil {
+		return err
+	}
+
+	// Buscar la partición dentro del MBR
+	particion, indexPartition := mbr.GetPartitionIndexByName(fdisk.name)
+	if particion != nil {
+		// No se puede eliminar una partición montada
+		if particion.Part_status[0] == '1' {
+			return fmt.Errorf("la particion %s esta montada, debe desmontarse antes de eliminarla", fdisk.name)
+		}
+
+		// Si es extendida se eliminan tambien todas sus particiones logicas
+		if particion.Part_type[0] == 'E' {
+			logicas, err := structures.GetEBRChain(fdisk.path, particion.Part_start)
+			if err != nil {
+				return fmt.Errorf("error al leer las particiones logicas: %s", err)
+			}
+			for _, logica := range logicas {
+				if logica.Ebr_mount[0] == '1' {
+					return fmt.Errorf("la particion logica %s esta montada, debe desmontarse antes de eliminar la extendida", logica.GetName())
+				}
+			}
+		}
+
+		inicio := particion.Part_start
+		tamano := particion.Part_size
+
+		// Se libera el slot del MBR
+		mbr.Mbr_partitions[indexPartition].ResetPartition()
+
+		err = mbr.SerializeMBR(fdisk.path)
+		if err != nil {
+			return err
+		}
+
+		// Con full se sobreescribe con ceros el espacio de la partición (incluye los EBR de la extendida)
+		if fdisk.del == "full" {
+			err = utils.ZeroFillRange(fdisk.path, int64(inicio), int64(tamano))
+			if err != nil {
+				return fmt.Errorf("error al llenar de ceros la particion: %s", err)
+			}
+		}
+		return nil
+	}
+
+	// Si no esta en el MBR se busca entre las particiones logicas
+	return deleteLogicPartition(fdisk, &mbr)
+}
+
+// Elimina una particion logica desenlazando su EBR de la cadena de la extendida
+func deleteLogicPartition(fdisk *FDISK, mbr *structures.MBR) error {
+	extendida := mbr.GetExtendedPartition2()
+	if extendida == nil {
+		return fmt.Errorf("no existe ninguna particion con el nombre: %s", fdisk.name)
+	}
+
+	// Se obtienen todos los EBR de la extendida
+	cadena, err := structures.GetEBRChain(fdisk.path, extendida.Part_start)
+	if err != nil {
+		return fmt.Errorf("error al leer las particiones logicas: %s", err)
+	}
+
+	for i, ebr := range cadena {
+		if ebr.IsEmpty() || !strings.EqualFold(ebr.GetName(), fdisk.name) {
+			continue
+		}
+
+		// No se puede eliminar una partición montada
+		if ebr.Ebr_mount[0] == '1' {
+			return fmt.Errorf("la particion %s esta montada, debe desmontarse antes de eliminarla", fdisk.name)
+		}
+
+		ebrSize := int32(binary.Size(ebr))
+		inicioDatos := ebr.Ebr_start + ebrSize
+		tamano := ebr.Ebr_size
+
+		// Se verifica si la siguiente es el EBR de cierre (vacío y sin siguiente)
+		siguienteVacio := i+1 < len(cadena) && cadena[i+1].IsEmpty() && cadena[i+1].Ebr_next == -1
+
+		if i == 0 || siguienteVacio {
+			// El primer EBR siempre debe de existir al inicio de la extendida, por eso solo se vacía.
+			// Si es la ultima logica, su EBR pasa a ser el EBR de cierre de la cadena
+			vacio := structures.EBR{
+				Ebr_mount: [1]byte{'N'},
+				Ebr_fit:   [1]byte{'N'},
+				Ebr_start: ebr.Ebr_start,
+				Ebr_size:  int32(-1),
+				Ebr_next:  ebr.Ebr_next,
+				Ebr_name:  [16]byte{'N'},
+			}
+			if siguienteVacio {
+				vacio.Ebr_next = -1
+			}
+			err = vacio.SerializeEBR(fdisk.path, ebr.Ebr_start)
+			if err != nil {
+				return err
+			}
+		} else {
+			// Se enlaza el EBR anterior con el siguiente
+			anterior := cadena[i-1]
+			anterior.Ebr_next = ebr.Ebr_next
+			err = anterior.SerializeEBR(fdisk.path, anterior.Ebr_start)
+			if err != nil {
+				return err
+			}
+			// El EBR tambien queda libre
+			inicioDatos = ebr.Ebr_start
+			tamano += ebrSize
+		}
+
+		// Con full se sobreescribe con ceros el espacio de la partición
+		if fdisk.del == "full" {
+			err = utils.ZeroFillRange(fdisk.path, int64(inicioDatos), int64(tamano))
+			if err != nil {
+				return fmt.Errorf("error al llenar de ceros la particion: %s", err)
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("no existe ninguna particion con el nombre: %s", fdisk.name)
+}
+
+// -------------------------------------------------------------Modificar Tamaño--------------------------------------------------------------
+//  1. Se obtiene el espacio libre que existe justo despues de la particion
+//  2. Si se agrega espacio, este no puede superar el espacio libre
+//  3. Si se quita espacio, no se puede dejar la particion por debajo de lo que ya esta en uso
+func commandFdiskAdd(fdisk *FDISK) error {
+	// Convertir el espacio a bytes
+	addBytes, err := utils.ConvertToBytes(fdisk.add, fdisk.unit)
+	if err != nil {
+		return fmt.Errorf("error al convertir las unidades de add: %s", err)
+	}
+
+	// Crear una instancia de MBR
+	var mbr structures.MBR
+
+	// Deserializar la estructura MBR desde un archivo binario
+	err = mbr.DeserializeMBR(fdisk.path)
+	if err != nil {
+		return err
+	}
+
+	// Buscar la partición dentro del MBR
+	particion, indexPartition := mbr.GetPartitionIndexByName(fdisk.name)
+	if particion == nil {
+		// Si no esta en el MBR se busca entre las particiones logicas
+		return addLogicPartition(fdisk, &mbr, int32(addBytes))
+	}
+
+	nuevoSize := particion.Part_size + int32(addBytes)
+	fin := particion.Part_start + nuevoSize
+
+	if addBytes > 0 {
+		// El limite es el inicio de la siguiente particion o el final del disco
+		limite := mbr.Mbr_size
+		for i, part := range mbr.Mbr_partitions {
+			if i == indexPartition || part.Part_start == -1 {
+				continue
+			}
+			if part.Part_start > particion.Part_start && part.Part_start < limite {
+				limite = part.Part_start
+			}
+		}
+		if fin > limite {
+			return fmt.Errorf("no hay espacio libre suficiente despues de la particion %s, disponible: %d bytes", fdisk.name, limite-(particion.Part_start+particion.Part_size))
+		}
+	} else {
+		// Se obtiene el espacio minimo que ya esta en uso dentro de la particion
+		minimo := int32(1)
+		if particion.Part_type[0] == 'E' {
+			logicas, err := structures.GetEBRChain(fdisk.path, particion.Part_start)
+			if err != nil {
+				return fmt.Errorf("error al leer las particiones logicas: %s", err)
+			}
+			for _, logica := range logicas {
+				finLogica := logica.Ebr_start + int32(binary.Size(logica))
+				if !logica.IsEmpty() {
+					finLogica += logica.Ebr_size
+				}
+				if finLogica-particion.Part_start > minimo {
+					minimo = finLogica - particion.Part_start
+				}
+			}
+		} else {
+			usado, err := usedFilesystemSize(fdisk.path, particion.Part_start)
+			if err != nil {
+				return err
+			}
+			if usado > minimo {
+				minimo = usado
+			}
+		}
+		if nuevoSize < minimo {
+			return fmt.Errorf("no se puede reducir la particion %s por debajo de %d bytes que ya estan en uso", fdisk.name, minimo)
+		}
+	}
+
+	// Se actualiza el tamaño de la partición
+	mbr.Mbr_partitions[indexPartition].Part_size = nuevoSize
+
+	// Serializar el MBR en el archivo binario
+	return mbr.SerializeMBR(fdisk.path)
+}
+
+// Modifica el tamaño de una particion logica dentro de la extendida
+func addLogicPartition(fdisk *FDISK, mbr *structures.MBR, addBytes int32) error {
+	extendida := mbr.GetExtendedPartition2()
+	if extendida == nil {
+		return fmt.Errorf("no existe ninguna particion con el nombre: %s", fdisk.name)
+	}
+	finExtendida := extendida.Part_start + extendida.Part_size
+
+	// Se obtienen todos los EBR de la extendida
+	cadena, err := structures.GetEBRChain(fdisk.path, extendida.Part_start)
+	if err != nil {
+		return fmt.Errorf("error al leer las particiones logicas: %s", err)
+	}
+
+	for i, ebr := range cadena {
+		if ebr.IsEmpty() || !strings.EqualFold(ebr.GetName(), fdisk.name) {
+			continue
+		}
+
+		ebrSize := int32(binary.Size(ebr))
+		inicioDatos := ebr.Ebr_start + ebrSize
+		nuevoSize := ebr.Ebr_size + addBytes
+		fin := inicioDatos + nuevoSize
+
+		// Se verifica si la siguiente es el EBR de cierre (vacío y sin siguiente)
+		siguienteVacio := i+1 < len(cadena) && cadena[i+1].IsEmpty() && cadena[i+1].Ebr_next == -1
+
+		if addBytes > 0 {
+			// El limite es el siguiente EBR o el final de la extendida
+			limite := finExtendida
+			if i+1 < len(cadena) && !siguienteVacio {
+				limite = cadena[i+1].Ebr_start
+			} else if siguienteVacio {
+				// Se debe de dejar espacio para el EBR de cierre
+				limite -= ebrSize
+			}
+			if fin > limite {
+				return fmt.Errorf("no hay espacio libre suficiente despues de la particion %s, disponible: %d bytes", fdisk.name, limite-(inicioDatos+ebr.Ebr_size))
+			}
+		} else {
+			// Se obtiene el espacio minimo que ya esta en uso dentro de la particion
+			minimo, err := usedFilesystemSize(fdisk.path, inicioDatos)
+			if err != nil {
+				return err
+			}
+			if minimo < 1 {
+				minimo = 1
+			}
+			if nuevoSize < minimo {
+				return fmt.Errorf("no se puede reducir la particion %s por debajo de %d bytes que ya estan en uso", fdisk.name, minimo)
+			}
+		}
+
+		// Se actualiza el EBR de la partición
+		ebr.Ebr_size = nuevoSize
+		if siguienteVacio {
+			// El EBR de cierre se mueve justo despues de la partición
+			ebr.Ebr_next = fin
+			err = createEBR_siguiente(fdisk, fin)
+			if err != nil {
+				return err
+			}
+		}
+		return ebr.SerializeEBR(fdisk.path, ebr.Ebr_start)
+	}
+
+	return fmt.Errorf("no existe ninguna particion con el nombre: %s", fdisk.name)
+}
+
+// Retorna los bytes que ocupa el sistema de archivos que inicia en start, 0 si no tiene formato
+func usedFilesystemSize(path string, start int32) (int32, error) {
+	var sb structures.SuperBlock
+	err := sb.Deserialize(path, int64(start))
+	if err != nil {
+		return 0, err
+	}
+
+	// Si no esta el numero magico la particion no tiene formato
+	if sb.S_magic != 0xEF53 {
+		return 0, nil
+	}
+
+	// El sistema de archivos ocupa hasta el final del area de bloques
+	totalBloques := sb.S_blocks_count + sb.S_free_blocks_count
+	return sb.S_block_start + totalBloques*sb.S_block_size - start, nil
+}
@@ -0,0 +1,152 @@
+package analyzer
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	structures "bakend/src/estructuras"
+)
+
+// Crea un disco de 100 KB con el ajuste indicado y las particiones en orden, retorna la ruta del disco
+func newTestDisk(t *testing.T, fit string, particiones []FDISK) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "Disco.mia")
+	err := commandMkdisk(&MKDISK{size: 100, unit: "K", fit: fit, path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, particion := range particiones {
+		particion.path = path
+		err := commandFdisk(&particion)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path
+}
+
+// Elimina las particiones indicadas con -delete=fast
+func deletePartitions(t *testing.T, path string, nombres ...string) {
+	t.Helper()
+
+	for _, nombre := range nombres {
+		err := commandFdiskDelete(&FDISK{path: path, name: nombre, del: "fast"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFdiskPrimaryFollowsDiskFit(t *testing.T) {
+	inicio := int32(binary.Size(structures.MBR{}))
+	kb := int32(1024)
+
+	// Quedan libres 20 KB al inicio, 10 KB en medio y el resto al final
+	particiones := []FDISK{
+		{size: 20, unit: "K", fit: "FF", typ: "P", name: "A"},
+		{size: 5, unit: "K", fit: "FF", typ: "P", name: "B"},
+		{size: 10, unit: "K", fit: "FF", typ: "P", name: "C"},
+		{size: 5, unit: "K", fit: "FF", typ: "P", name: "D"},
+	}
+	pruebas := []struct {
+		fit  string
+		want int32
+	}{
+		{"FF", inicio},
+		{"BF", inicio + 25*kb},
+		{"WF", inicio + 40*kb},
+	}
+	for _, prueba := range pruebas {
+		t.Run(prueba.fit, func(t *testing.T) {
+			path := newTestDisk(t, prueba.fit, particiones)
+			deletePartitions(t, path, "A", "C")
+
+			err := commandFdisk(&FDISK{size: 8, unit: "K", fit: "FF", path: path, typ: "P", name: "E"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var mbr structures.MBR
+			err = mbr.DeserializeMBR(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			particion, _ := mbr.GetPartitionByName("E")
+			if particion == nil {
+				t.Fatal("no se creo la particion E")
+			}
+			if particion.Part_start != prueba.want {
+				t.Errorf("la particion inicia en %d, se esperaba %d", particion.Part_start, prueba.want)
+			}
+		})
+	}
+}
+
+func TestFdiskRejectsWhenNoGapFits(t *testing.T) {
+	path := newTestDisk(t, "FF", []FDISK{
+		{size: 40, unit: "K", fit: "FF", typ: "P", name: "A"},
+		{size: 40, unit: "K", fit: "FF", typ: "P", name: "B"},
+	})
+	deletePartitions(t, path, "A")
+
+	// Hay 60 KB libres pero en dos espacios de 40 KB y 20 KB
+	err := commandFdisk(&FDISK{size: 50, unit: "K", fit: "FF", path: path, typ: "P", name: "C"})
+	if err == nil {
+		t.Fatal("se esperaba un error sin un espacio libre suficiente")
+	}
+}
+
+func TestFdiskLogicalFollowsExtendedFit(t *testing.T) {
+	ebrSize := int32(binary.Size(structures.EBR{}))
+	kb := int32(1024)
+
+	// Dentro de la extendida quedan libres el espacio de L1 (20 KB) y el de L3 (10 KB)
+	particiones := []FDISK{
+		{size: 60, unit: "K", typ: "E", name: "EXT"},
+		{size: 20, unit: "K", fit: "FF", typ: "L", name: "L1"},
+		{size: 5, unit: "K", fit: "FF", typ: "L", name: "L2"},
+		{size: 10, unit: "K", fit: "FF", typ: "L", name: "L3"},
+		{size: 5, unit: "K", fit: "FF", typ: "L", name: "L4"},
+	}
+	pruebas := []struct {
+		fit  string
+		want func(extendida int32) int32 // Inicio del EBR de la nueva logica
+	}{
+		{"FF", func(extendida int32) int32 { return extendida }},
+		{"BF", func(extendida int32) int32 { return extendida + 25*kb + 2*ebrSize }},
+	}
+	for _, prueba := range pruebas {
+		t.Run(prueba.fit, func(t *testing.T) {
+			for i := range particiones {
+				if particiones[i].typ == "E" {
+					particiones[i].fit = prueba.fit
+				}
+			}
+			path := newTestDisk(t, "FF", particiones)
+			deletePartitions(t, path, "L1", "L3")
+
+			err := commandFdisk(&FDISK{size: 4, unit: "K", fit: "FF", path: path, typ: "L", name: "L5"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var mbr structures.MBR
+			err = mbr.DeserializeMBR(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			extendida := mbr.GetExtendedPartition2()
+			cadena, err := structures.GetEBRChain(path, extendida.Part_start)
+			if err != nil {
+				t.Fatal(err)
+			}
+			logica := structures.GetLogicalByName(cadena, "L5")
+			if logica == nil {
+				t.Fatal("no se creo la particion L5")
+			}
+			if want := prueba.want(extendida.Part_start); logica.Ebr_start != want {
+				t.Errorf("el EBR de la logica inicia en %d, se esperaba %d", logica.Ebr_start, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	stores "bakend/src/almacenamiento"
+	utils "bakend/src/utils"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// FIND estructura que representa el comando find con sus parámetros
+type FIND struct {
+	path     string // Carpeta desde donde se busca
+	name     string // Patrón del nombre, acepta ? y *
+	textObte string
+}
+
+/*
+   find -path=/ -name=*.txt
+   find -path=/home -name=?.*
+*/
+
+func ParseFind(tokens []string) (*FIND, error) {
+	cmd := &FIND{} // Crea una nueva instancia de FIND
+
+	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
+	args := strings.Join(tokens, " ")
+	// Expresión regular para encontrar los parámetros del comando find
+	re := regexp.MustCompile(`-(?i:path="[^"]+"|path=[^\s]+|name="[^"]+"|name=[^\s]+)`)
+	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
+	matches := re.FindAllString(args, -1)
+
+	// Itera sobre cada coincidencia encontrada
+	for _, match := range matches {
+		// Divide cada parte en clave y valor usando "=" como delimitador
+		kv := strings.SplitN(match, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+
+		// Remove comillas si estan present
+		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+			value = strings.Trim(value, "\"")
+		}
+
+		// Switch para manejar diferentes parámetros
+		switch key {
+		case "-path":
+			cmd.path = value
+		case "-name":
+			cmd.name = value
+		default:
+			// Si el parámetro no es reconocido, devuelve un error
+			return nil, fmt.Errorf("parámetro desconocido: %s", key)
+		}
+	}
+
+	// Verifica que los parámetros -path y -name hayan sido proporcionados
+	if cmd.path == "" {
+		return nil, errors.New("faltan parámetros requeridos: -path")
+	}
+	if cmd.name == "" {
+		return nil, errors.New("faltan parámetros requeridos: -name")
+	}
+
+	err := commandFind(cmd)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return nil, err
+	}
+
+	return cmd, fmt.Errorf("%s", cmd.textObte)
+}
+
+// Se busca con los permisos del usuario logeado
+func commandFind(find *FIND) error {
+	//Obtenemos el usuario logeado
+	var usuario = ObtenerUsuari()
+
+	// Obtener la partición montada
+	partitionSuperblock, _, partitionPath, err := stores.GetMountedPartitionSuperblock(usuario.id)
+	if err != nil {
+		return fmt.Errorf("error al obtener la partición montada: %w", err)
+	}
+
+	// La carpeta de inicio completa
+	parentDirs, destDir := utils.GetParentDirectories(find.path)
+
+	arbol, err := partitionSuperblock.FindPath(partitionPath, append(parentDirs, destDir), find.name, usuario.uid, usuario.gid)
+	if err != nil {
+		return fmt.Errorf("error en el find: %w", err)
+	}
+
+	find.textObte = "***************** FIND ********************\n" + arbol
+	return nil
+}
@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	stores "bakend/src/almacenamiento"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// FSCK estructura que representa el comando fsck con sus parámetros
+type FSCK struct {
+	id     string // ID de la partición montada
+	repair bool   // Reparar los bitmaps y los contadores
+}
+
+/*
+	fsck -id=271A
+	fsck -id=271A -repair
+*/
+
+// ParseFsck parsea el comando fsck y devuelve una instancia de FSCK
+func ParseFsck(tokens []string) (*FSCK, error) {
+	cmd := &FSCK{} // Crea una nueva instancia de FSCK
+
+	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
+	args := strings.Join(tokens, " ")
+	// Expresión regular para encontrar los parámetros del comando fsck
+	re := regexp.MustCompile(`-(?i:id=[^\s]+|repair)`)
+	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
+	matches := re.FindAllString(args, -1)
+
+	// Itera sobre cada coincidencia encontrada
+	for _, match := range matches {
+		// Divide cada parte en clave y valor usando "=" como delimitador
+		kv := strings.SplitN(match, "=", 2)
+		key := strings.ToLower(kv[0])
+
+		// Switch para manejar diferentes parámetros
+		switch key {
+		case "-id":
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
+			}
+			cmd.id = kv[1]
+		case "-repair":
+			cmd.repair = true
+		default:
+			// Si el parámetro no es reconocido, devuelve un error
+			return nil, fmt.Errorf("parámetro desconocido: %s", key)
+		}
+	}
+
+	// Verifica que el parámetro -id haya sido proporcionado
+	if cmd.id == "" {
+		return nil, errors.New("faltan parámetros requeridos: -id")
+	}
+
+	// Validamos el sistema de archivos
+	resultado, err := commandFsck(cmd)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return nil, err
+	}
+
+	return cmd, errors.New(resultado)
+}
+
+func commandFsck(fsck *FSCK) (string, error) {
+	// Obtener la partición montada
+	partitionSuperblock, mountedPartition, partitionPath, err := stores.GetMountedPartitionSuperblock(fsck.id)
+	if err != nil {
+		return "", fmt.Errorf("error al obtener la partición montada: %w", err)
+	}
+
+	issues, err := partitionSuperblock.CheckFilesystem(partitionPath, fsck.repair)
+	if err != nil {
+		return "", fmt.Errorf("error en el fsck: %w", err)
+	}
+
+	// Los contadores reparados se guardan en el superbloque
+	if fsck.repair && len(issues) > 0 {
+		err = partitionSuperblock.Serialize(partitionPath, int64(mountedPartition.Part_start))
+		if err != nil {
+			return "", fmt.Errorf("error al serializar el superbloque: %w", err)
+		}
+	}
+
+	if len(issues) == 0 {
+		return fmt.Sprintf("fsck %s: no se encontraron problemas", fsck.id), nil
+	}
+
+	// Se muestra un problema por linea
+	resultado := fmt.Sprintf("fsck %s: %d problema(s) encontrados", fsck.id, len(issues))
+	for _, issue := range issues {
+		resultado += "\n" + issue.String()
+	}
+	return resultado, nil
+}
@@ -0,0 +1,91 @@
+package analyzer
+
+import (
+	structures "bakend/src/estructuras"
+	utils "bakend/src/utils"
+	"fmt"
+	"strings"
+)
+
+// Registra la operación del usuario en el journal, en ext2 no se registra nada
+func registrarJournal(sb *structures.SuperBlock, path string, operacion string, ruta string, contenido string, usuario *LOGIN) error {
+	err := sb.AddJournal(path, operacion, ruta, contenido, usuario.uid, usuario.gid)
+	if err != nil {
+		return fmt.Errorf("error al registrar la operación en el journal: %w", err)
+	}
+	return nil
+}
+
+// Contenido de chmod y chown en el journal, con ",r" si el cambio fue recursivo
+func journalFlag(valor string, recursivo bool) string {
+	if recursivo {
+		return valor + ",r"
+	}
+	return valor
+}
+
+// Vuelve a ejecutar una operación del journal con el usuario que la realizó
+// Los padres siempre se crean porque la operación original ya fue valida
+func replayJournal(path string, sb *structures.SuperBlock, mountedPartition *structures.PARTITION, entrada structures.JournalEntry) error {
+	usuario := &LOGIN{uid: entrada.Uid, gid: entrada.Gid}
+	parentDirs, nombre := utils.GetParentDirectories(entrada.Ruta)
+	contenido := entrada.Contenido
+
+	switch entrada.Operacion {
+	case "mkdir":
+		return sb.CreateFolder(true, path, parentDirs, nombre, usuario.uid, usuario.gid)
+	case "mkfile":
+		return sb.CreateFile(true, path, parentDirs, nombre, contenido, usuario.uid, usuario.gid)
+	case "edit", "append":
+		return sb.EditFile(path, parentDirs, nombre, contenido, entrada.Operacion == "append", usuario.uid, usuario.gid)
+	case "remove":
+		return sb.RemovePath(path, parentDirs, nombre, usuario.uid, usuario.gid)
+	case "rename":
+		return sb.RenamePath(path, parentDirs, nombre, contenido, usuario.uid, usuario.gid)
+	case "copy", "move":
+		destDirs, destDir := utils.GetParentDirectories(contenido)
+		if entrada.Operacion == "copy" {
+			return sb.CopyPath(path, parentDirs, nombre, append(destDirs, destDir), usuario.uid, usuario.gid)
+		}
+		return sb.MovePath(path, parentDirs, nombre, append(destDirs, destDir), usuario.uid, usuario.gid)
+	case "link", "symlink":
+		return crearEnlace(sb, path, contenido, entrada.Ruta, entrada.Operacion == "symlink", usuario)
+	case "chmod":
+		ugo, recursivo := strings.CutSuffix(contenido, ",r")
+		if len(ugo) != 3 {
+			return fmt.Errorf("error permisos invalidos en el journal: %s", ugo)
+		}
+		return sb.ChmodPath(path, append(parentDirs, nombre), [3]byte{ugo[0], ugo[1], ugo[2]}, recursivo, usuario.uid, usuario.gid)
+	case "chown":
+		propietario, recursivo := strings.CutSuffix(contenido, ",r")
+		_, lines, err := leerUsersTxt(path, 1, sb)
+		if err != nil {
+			return err
+		}
+		uid, gid, err := buscarUsuario(lines, propietario)
+		if err != nil {
+			return err
+		}
+		return sb.ChownPath(path, append(parentDirs, nombre), uid, gid, recursivo, usuario.uid, usuario.gid)
+	case "mkgrp":
+		return MkgprComand(path, usuario, &MKGRP{name: contenido}, 1, sb, mountedPartition)
+	case "rmgrp":
+		return RmgrpComand(path, usuario, &RMGRP{name: contenido}, 1, sb, mountedPartition)
+	case "mkusr":
+		valores := strings.Split(contenido, ",")
+		if len(valores) != 3 {
+			return fmt.Errorf("error usuario invalido en el journal: %s", contenido)
+		}
+		return MkusrComand(path, usuario, &MKUSR{user: valores[0], pass: valores[1], grp: valores[2]}, 1, sb, mountedPartition)
+	case "rmusr":
+		return RmuserComand(path, usuario, &RMUSR{user: contenido}, 1, sb, mountedPartition)
+	case "chgrp":
+		valores := strings.Split(contenido, ",")
+		if len(valores) != 2 {
+			return fmt.Errorf("error cambio de grupo invalido en el journal: %s", contenido)
+		}
+		return ChgrpComand(path, usuario, &CHGRP{user: valores[0], grp: valores[1]}, 1, sb, mountedPartition)
+	default:
+		return fmt.Errorf("error operación desconocida en el journal: %s", entrada.Operacion)
+	}
+}
@@ -0,0 +1,120 @@
+package analyzer
+
+import (
+	stores "bakend/src/almacenamiento"
+	structures "bakend/src/estructuras"
+	utils "bakend/src/utils"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// LN estructura que representa el comando ln con sus parámetros
+type LN struct {
+	path    string // Path del archivo al que apunta el enlace
+	destino string // Path completo del enlace que se crea
+	s       bool   // Opción -s (enlace simbolico en lugar de enlace duro)
+}
+
+/*
+   ln -path=/home/user/docs/a.txt -destino=/home/a_link.txt
+   ln -s -path="/home/mis documentos" -destino=/docs
+*/
+
+func ParseLn(tokens []string) (*LN, error) {
+	cmd := &LN{} // Crea una nueva instancia de LN
+
+	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
+	args := strings.Join(tokens, " ")
+	// Expresión regular para encontrar los parámetros del comando ln
+	re := regexp.MustCompile(`-(?i:path="[^"]+"|path=[^\s]+|destino="[^"]+"|destino=[^\s]+|s)`)
+	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
+	matches := re.FindAllString(args, -1)
+
+	// Itera sobre cada coincidencia encontrada
+	for _, match := range matches {
+		// Divide cada parte en clave y valor usando "=" como delimitador
+		kv := strings.SplitN(match, "=", 2)
+		key := strings.ToLower(kv[0])
+
+		// Switch para manejar diferentes parámetros
+		switch key {
+		case "-path", "-destino":
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
+			}
+			value := kv[1]
+			// Remove comillas si estan present
+			if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+				value = strings.Trim(value, "\"")
+			}
+			if key == "-path" {
+				cmd.path = value
+			} else {
+				cmd.destino = value
+			}
+		case "-s":
+			cmd.s = true
+		default:
+			// Si el parámetro no es reconocido, devuelve un error
+			return nil, fmt.Errorf("parámetro desconocido: %s", key)
+		}
+	}
+
+	// Verifica que los parámetros -path y -destino hayan sido proporcionados
+	if cmd.path == "" {
+		return nil, errors.New("faltan parámetros requeridos: -path")
+	}
+	if cmd.destino == "" {
+		return nil, errors.New("faltan parámetros requeridos: -destino")
+	}
+
+	err := commandLn(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd, fmt.Errorf("enlace creado correctamente LN: %+v", *cmd)
+}
+
+// El enlace se crea con el usuario logeado como propietario
+func commandLn(ln *LN) error {
+	//Obtenemos el usuario logeado
+	var usuario = ObtenerUsuari()
+
+	// Obtener la partición montada
+	partitionSuperblock, mountedPartition, partitionPath, err := stores.GetMountedPartitionSuperblock(usuario.id)
+	if err != nil {
+		return fmt.Errorf("error al obtener la partición montada: %w", err)
+	}
+
+	err = crearEnlace(partitionSuperblock, partitionPath, ln.path, ln.destino, ln.s, usuario)
+	if err != nil {
+		return fmt.Errorf("error al crear el enlace: %w", err)
+	}
+
+	// Serializar el superbloque con los inodos y bloques reservados
+	err = partitionSuperblock.Serialize(partitionPath, int64(mountedPartition.Part_start))
+	if err != nil {
+		return fmt.Errorf("error al serializar el superbloque: %w", err)
+	}
+
+	// Registrar la operación en el journal (solo en ext3)
+	operacion := "link"
+	if ln.s {
+		operacion = "symlink"
+	}
+	return registrarJournal(partitionSuperblock, partitionPath, operacion, ln.destino, ln.path, usuario)
+}
+
+// Crea el enlace destino que apunta a ruta, también se usa al recuperar el journal
+func crearEnlace(sb *structures.SuperBlock, path string, ruta string, destino string, simbolico bool, usuario *LOGIN) error {
+	// GetParentDirectories obtiene las carpetas padres y el nombre del enlace
+	parentDirs, nombre := utils.GetParentDirectories(destino)
+	if simbolico {
+		return sb.Symlink(path, ruta, parentDirs, nombre, usuario.uid, usuario.gid)
+	}
+	targetDirs, targetName := utils.GetParentDirectories(ruta)
+	return sb.HardLink(path, append(targetDirs, targetName), parentDirs, nombre, usuario.uid, usuario.gid)
+}
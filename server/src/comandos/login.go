@@ -15,6 +15,8 @@ type LOGIN struct {
 	user string //Almacenara el usuario
 	pass string // Alamcenara la contraseña
 	id   string //Almacenara el id de la particion
+	uid  int32  //Id del usuario en users.txt
+	gid  int32  //Id del grupo del usuario en users.txt
 }
 
 /*
@@ -119,79 +121,45 @@ func commandLogear(login *LOGIN) error {
 	return nil
 }
 
-// TODO: validar si es usuario y si no esta eliminado, tambien si no esta en otro bloque
+// Valida que el usuario exista y no este eliminado, users.txt se lee completo aunque ocupe bloques indirectos
 // Funcion para accder al archivo de user.txt
 // Login: path del disco, objeto con los datos del usuario, el inicio de los inodos
 func Login(path string, login *LOGIN, inodeIndex int32, sb *structures.SuperBlock) error {
-	//Se crea una instancia de un objeto de tipo Inode
-	inode := &structures.Inode{}
-
-	// Deserializar el inodo
-	err := inode.Deserialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
+	//Obtengo el texto completo del archivo users.txt
+	_, lines, err := leerUsersTxt(path, inodeIndex, sb)
 	if err != nil {
 		return err
 	}
 
-	//Aca se almacenara el contenido de user.txt
-	data := ""
-	// Iterar sobre cada bloque del inodo (apuntadores)
-	for _, blockIndex := range inode.I_block {
-		// Si el bloque no existe, salir
-		if blockIndex == -1 {
-			break
-		}
-
-		// Crear un nuevo bloque de archivo
-		block := &structures.FileBlock{}
-
-		// Deserializar el bloque desde el incio de los blokes  + posicion por el peso de los bloques  que es 64
-		err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size))) // 64 porque es el tamaño de un bloque
-		if err != nil {
-			return err
-		}
-
-		//Concateno el texto del archivo uset.txt
-		data += strings.Trim(string(block.B_content[:]), "\x00 ")
-
-	}
-
-	/*
-		1, G, root
-		1, U, root, root, 123
-	*/
-
-	// Dividir por salto de línea
-	lines := strings.Split(data, "\n")
-	// Recorrer cada línea y dividir por comas
+	// Recorrer cada línea y validar el usuario y la contraseña
+	encontrado := false
 	for _, line := range lines {
 		values := strings.Split(line, ",")
 
-		// Almacenar en variables según la cantidad de datos
-		//Esto son los grupos
-		if len(values) == 3 {
-			//id, tipo, nombre := values[0], values[1], values[2]
-			//fmt.Printf("ID: %s, Tipo: %s, Nombre: %s\n", id, tipo, nombre)
-		} else if len(values) == 5 {
-			//Estos son los usuarios
-			numeral, _, _, nombre, extra := values[0], values[1], values[2], values[3], values[4]
-			if nombre == login.user && extra == login.pass {
-				//Esto valida que el usuario no este eliminado
-				if numeral == "0" {
-					logeado = false
-					return fmt.Errorf("error con el suario: %s este ya se encuntra eliminado", nombre)
-				}
-				logeado = true
-				//fmt.Println("Logeado")
+		// Las lineas de 5 datos son los usuarios
+		if len(values) == 5 && values[3] == login.user && values[4] == login.pass {
+			//Esto valida que el usuario no este eliminado
+			if values[0] == "0" {
+				logeado = false
+				return fmt.Errorf("error con el suario: %s este ya se encuntra eliminado", login.user)
 			}
-
-			//fmt.Printf("ID: %s, Tipo: %s, Nombre: %s, Extra: %s\n", id, tipo, nombre, extra)
+			encontrado = true
 		}
 	}
 
-	if !logeado {
+	if !encontrado {
 		return fmt.Errorf("error el suario: %s ó contraseña no existe: %s", login.user, login.pass)
 	}
 
+	// El uid y el gid se obtienen igual que en los comandos que usan users.txt
+	uid, gid, err := buscarUsuario(lines, login.user)
+	if err != nil {
+		return err
+	}
+	login.uid = uid
+	login.gid = gid
+	logeado = true
+
 	return nil
 }
 
@@ -203,12 +171,33 @@ func Logout(tokens []string) (*LOGIN, error) {
 		cmd.id = ""
 		cmd.pass = ""
 		cmd.user = ""
+		cmd.uid = 0
+		cmd.gid = 0
 		return nil, errors.New("usuario deslogeado")
 	}
 
 	return nil, errors.New("no puede deslogearse si no existe un usuario logeado")
 }
 
+// Cierra la sesion si el usuario logeado esta usando la particion con el id indicado
+func LogoutParticion(id string) bool {
+	if ParticionEnUso(id) {
+		logeado = false
+		cmd.id = ""
+		cmd.pass = ""
+		cmd.user = ""
+		cmd.uid = 0
+		cmd.gid = 0
+		return true
+	}
+	return false
+}
+
+// Indica si el usuario logeado esta usando la particion con el id indicado
+func ParticionEnUso(id string) bool {
+	return logeado && strings.EqualFold(cmd.id, id)
+}
+
 func ObtenerLogin() bool {
 	return logeado
 }
@@ -0,0 +1,78 @@
+package analyzer
+
+import (
+	stores "bakend/src/almacenamiento"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// LOSS estructura que representa el comando loss con sus parámetros
+type LOSS struct {
+	id string // ID de la partición montada
+}
+
+/*
+   loss -id=271A
+*/
+
+func ParseLoss(tokens []string) (*LOSS, error) {
+	cmd := &LOSS{} // Crea una nueva instancia de LOSS
+
+	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
+	args := strings.Join(tokens, " ")
+	// Expresión regular para encontrar los parámetros del comando loss
+	re := regexp.MustCompile(`-(?i:id=[^\s]+)`)
+	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
+	matches := re.FindAllString(args, -1)
+
+	// Itera sobre cada coincidencia encontrada
+	for _, match := range matches {
+		// Divide cada parte en clave y valor usando "=" como delimitador
+		kv := strings.SplitN(match, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+
+		// Switch para manejar diferentes parámetros
+		switch key {
+		case "-id":
+			cmd.id = value
+		default:
+			// Si el parámetro no es reconocido, devuelve un error
+			return nil, fmt.Errorf("parámetro desconocido: %s", key)
+		}
+	}
+
+	// Verifica que el parámetro -id haya sido proporcionado
+	if cmd.id == "" {
+		return nil, errors.New("faltan parámetros requeridos: -id")
+	}
+
+	err := commandLoss(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd, fmt.Errorf("perdida del sistema de archivos simulada LOSS: %+v", *cmd)
+}
+
+// Borra los bitmaps, los inodos y los bloques, solo se puede recuperar con el journal
+func commandLoss(loss *LOSS) error {
+	// Obtener la partición montada
+	partitionSuperblock, _, partitionPath, err := stores.GetMountedPartitionSuperblock(loss.id)
+	if err != nil {
+		return fmt.Errorf("error al obtener la partición montada: %w", err)
+	}
+	if !partitionSuperblock.IsExt3() {
+		return errors.New("error el comando loss solo se puede usar en particiones ext3")
+	}
+
+	err = partitionSuperblock.WipeFilesystem(partitionPath)
+	if err != nil {
+		return fmt.Errorf("error al limpiar el sistema de archivos: %w", err)
+	}
+	return nil
+}
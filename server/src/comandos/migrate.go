@@ -0,0 +1,113 @@
+package analyzer
+
+import (
+	stores "bakend/src/almacenamiento"
+	structures "bakend/src/estructuras"
+	utils "bakend/src/utils"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MIGRATE estructura que representa el comando migrate con sus parámetros
+type MIGRATE struct {
+	id string // ID de la partición montada
+}
+
+/*
+	migrate -id=271A
+*/
+
+// ParseMigrate parsea el comando migrate y devuelve una instancia de MIGRATE
+func ParseMigrate(tokens []string) (*MIGRATE, error) {
+	cmd := &MIGRATE{} // Crea una nueva instancia de MIGRATE
+
+	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
+	args := strings.Join(tokens, " ")
+	// Expresión regular para encontrar los parámetros del comando migrate
+	re := regexp.MustCompile(`-(?i:id=[^\s]+)`)
+	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
+	matches := re.FindAllString(args, -1)
+
+	// Itera sobre cada coincidencia encontrada
+	for _, match := range matches {
+		// Divide cada parte en clave y valor usando "=" como delimitador
+		kv := strings.SplitN(match, "=", 2)
+		key := strings.ToLower(kv[0])
+
+		// Switch para manejar diferentes parámetros
+		switch key {
+		case "-id":
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
+			}
+			cmd.id = kv[1]
+		default:
+			// Si el parámetro no es reconocido, devuelve un error
+			return nil, fmt.Errorf("parámetro desconocido: %s", key)
+		}
+	}
+
+	// Verifica que el parámetro -id haya sido proporcionado
+	if cmd.id == "" {
+		return nil, errors.New("faltan parámetros requeridos: -id")
+	}
+
+	err := commandMigrate(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd, fmt.Errorf("sistema de archivos migrado al formato %d MIGRATE: %+v", structures.FormatVersion, *cmd)
+}
+
+// El contenido se lee completo a memoria antes de formatear, si no cabe en el formato actual la partición no se modifica
+func commandMigrate(migrate *MIGRATE) error {
+	// Obtener la partición montada
+	mountedPartition, partitionPath, err := stores.GetMountedPartition(migrate.id)
+	if err != nil {
+		return err
+	}
+
+	legacy, err := structures.ReadLegacyFilesystem(partitionPath, int64(mountedPartition.Part_start))
+	if err != nil {
+		return err
+	}
+
+	// Se conserva el tipo de sistema de archivos, el tamaño de bloque y el ratio
+	fs := "2fs"
+	if legacy.FilesystemType == 3 {
+		fs = "3fs"
+	}
+	n := calculateN(mountedPartition, fs, legacy.Ratio, legacy.BlockSize)
+	err = legacy.Fits(n)
+	if err != nil {
+		return err
+	}
+
+	err = utils.ZeroFillRange(partitionPath, int64(mountedPartition.Part_start), int64(mountedPartition.Part_size))
+	if err != nil {
+		return fmt.Errorf("error al limpiar la partición: %w", err)
+	}
+
+	superBlock := createSuperBlock(mountedPartition, n, fs, legacy.Ratio, legacy.BlockSize)
+	if superBlock.IsExt3() {
+		err = superBlock.CreateJournal(partitionPath)
+		if err != nil {
+			return err
+		}
+	}
+	err = superBlock.CreateBitMaps(partitionPath)
+	if err != nil {
+		return err
+	}
+
+	err = superBlock.RestoreLegacy(partitionPath, legacy)
+	if err != nil {
+		return fmt.Errorf("error al restaurar el contenido: %w", err)
+	}
+
+	// Serializar el superbloque
+	return superBlock.Serialize(partitionPath, int64(mountedPartition.Part_start))
+}
@@ -83,7 +83,7 @@ func commandMkdir(mkdir *MKDIR) error {
 	}
 
 	// Crear el directorio
-	err = createDirectory(mkdir, partitionSuperblock, partitionPath, mountedPartition)
+	err = createDirectory(mkdir, partitionSuperblock, partitionPath, mountedPartition, usuario)
 	if err != nil {
 		err = fmt.Errorf("error al crear el directorio: %w", err)
 	}
@@ -91,7 +91,7 @@ func commandMkdir(mkdir *MKDIR) error {
 	return err
 }
 
-func createDirectory(mkdir *MKDIR, sb *structures.SuperBlock, partitionPath string, mountedPartition *structures.PARTITION) error {
+func createDirectory(mkdir *MKDIR, sb *structures.SuperBlock, partitionPath string, mountedPartition *structures.PARTITION, usuario *LOGIN) error {
 	//fmt.Println("\nCreando directorio:", dirPath)
 
 	// GetParentDirectories obtiene las carpetas padres y el directorio de destino
@@ -100,7 +100,7 @@ func createDirectory(mkdir *MKDIR, sb *structures.SuperBlock, partitionPath stri
 	// fmt.Println("Directorio destino:", destDir)
 
 	// Crear el directorio segun el path proporcionado
-	err := sb.CreateFolder(mkdir.p, partitionPath, parentDirs, destDir)
+	err := sb.CreateFolder(mkdir.p, partitionPath, parentDirs, destDir, usuario.uid, usuario.gid)
 	if err != nil {
 		return fmt.Errorf("error al crear el directorio: %w", err)
 	}
@@ -115,5 +115,6 @@ func createDirectory(mkdir *MKDIR, sb *structures.SuperBlock, partitionPath stri
 		return fmt.Errorf("error al serializar el superbloque: %w", err)
 	}
 
-	return nil
+	// Registrar la operación en el journal (solo en ext3)
+	return registrarJournal(sb, partitionPath, "mkdir", mkdir.path, "", usuario)
 }
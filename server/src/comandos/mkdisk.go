@@ -18,10 +18,11 @@ import (
 // MKDIR representa la estructura para el comando mkdir
 // Contiene los parámetros necesarios para crear directorios
 type MKDISK struct {
-	size int    // Tamaño del disco
-	unit string // Unidad de medida del tamaño (K o M)
-	fit  string // Tipo de ajuste (BF, FF, WF)
-	path string // Ruta del archivo del disco
+	size   int    // Tamaño del disco
+	unit   string // Unidad de medida del tamaño (K o M)
+	fit    string // Tipo de ajuste (BF, FF, WF)
+	path   string // Ruta del archivo del disco
+	sparse bool   // Crear el archivo disperso, solo se escribe el MBR
 }
 
 /*
@@ -29,6 +30,7 @@ type MKDISK struct {
    mkdisk -size=3000 -path=/home/user/Disco1.mia
    mkdisk -size=5 -unit=M -fit=WF -path="/home/keviin/University/PRACTICAS/MIA_LAB_S2_2024/CLASE03/disks/Disco1.mia"
    mkdisk -Size=10 -path="/home/mis discos/Disco4.mia"
+   mkdisk -size=500 -unit=M -path=/home/user/Disco5.mia -sparse
 */
 
 func ParseMkdisk(tokens []string) (*MKDISK, error) {
@@ -39,7 +41,7 @@ func ParseMkdisk(tokens []string) (*MKDISK, error) {
 	//args = strings.ToLower(args)
 	//fmt.Println(args)
 	// Expresión regular para encontrar los parámetros del comando mkdisk
-	re := regexp.MustCompile(`-(?i:size=\d+|unit=[kKmM]|fit=[bBfFwW]{2}|path="[^"]+"|path=[^\s]+)`)
+	re := regexp.MustCompile(`-(?i:size=\d+|unit=[kKmM]|fit=[bBfFwW]{2}|path="[^"]+"|path=[^\s]+|sparse)`)
 	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
 	matches := re.FindAllString(args, -1)
 
@@ -54,6 +56,12 @@ func ParseMkdisk(tokens []string) (*MKDISK, error) {
 
 	// Itera sobre cada coincidencia encontrada
 	for _, match := range matches {
+		// El parámetro -sparse no lleva valor
+		if strings.EqualFold(match, "-sparse") {
+			cmd.sparse = true
+			continue
+		}
+
 		// Divide cada parte en clave y valor usando "=" como delimitador
 		kv := strings.SplitN(match, "=", 2)
 		if len(kv) != 2 {
@@ -171,6 +179,12 @@ func createDisk(mkdisk *MKDISK, sizeBytes int) error {
 	}
 	defer file.Close()
 
+	// En modo disperso solo se fija el tamaño del archivo, el sistema no reserva los bloques
+	// y las zonas que nunca se escriben se leen como ceros
+	if mkdisk.sparse {
+		return file.Truncate(int64(sizeBytes))
+	}
+
 	// Escribir en el archivo usando un buffer de 1 MB
 	buffer := make([]byte, 1024*1024) // Crea un buffer de 1 MB
 	for sizeBytes > 0 {
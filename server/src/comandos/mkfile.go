@@ -110,7 +110,7 @@ func commandMkfile(mkfile *MKFILE) error {
 	}
 
 	// Crear el directorio
-	err = createFile(mkfile, partitionSuperblock, partitionPath, mountedPartition)
+	err = createFile(mkfile, partitionSuperblock, partitionPath, mountedPartition, usuario)
 	if err != nil {
 		err = fmt.Errorf("error al crear el archivo: %w", err)
 	}
@@ -118,7 +118,7 @@ func commandMkfile(mkfile *MKFILE) error {
 	return err
 }
 
-func createFile(mkfile *MKFILE, sb *structures.SuperBlock, partitionPath string, mountedPartition *structures.PARTITION) error {
+func createFile(mkfile *MKFILE, sb *structures.SuperBlock, partitionPath string, mountedPartition *structures.PARTITION, usuario *LOGIN) error {
 	//fmt.Println("\nCreando directorio y archivo:", mkfile.path)
 
 	// GetParentDirectories obtiene las carpetas padres y el directorio de destino
@@ -144,7 +144,7 @@ func createFile(mkfile *MKFILE, sb *structures.SuperBlock, partitionPath string,
 	}
 
 	//Aca ya se debe de generar el archivo en el disco virtual con el -path
-	err := sb.CreateFile(mkfile.r, partitionPath, parentDirs, nombreArchivo, contenido)
+	err := sb.CreateFile(mkfile.r, partitionPath, parentDirs, nombreArchivo, contenido, usuario.uid, usuario.gid)
 	if err != nil {
 		return fmt.Errorf("error al crear el archivo en CreateFile: %w", err)
 	}
@@ -155,7 +155,8 @@ func createFile(mkfile *MKFILE, sb *structures.SuperBlock, partitionPath string,
 		return fmt.Errorf("error al serializar el superbloque: %w", err)
 	}
 
-	return nil
+	// Registrar la operación en el journal (solo en ext3)
+	return registrarJournal(sb, partitionPath, "mkfile", mkfile.path, contenido, usuario)
 }
 
 // LeerArchivo recibe una ruta y devuelve el contenido del archivo como string
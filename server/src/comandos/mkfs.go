@@ -3,24 +3,33 @@ package analyzer
 import (
 	stores "bakend/src/almacenamiento"
 	structures "bakend/src/estructuras"
+	utils "bakend/src/utils"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"math"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
 
 // MKFS estructura que representa el comando mkfs con sus parámetros
 type MKFS struct {
-	id  string // ID del disco
-	typ string // Tipo de formato (full)
+	id    string // ID del disco
+	typ   string // Tipo de formato (full o fast)
+	fs    string // Sistema de archivos (2fs o 3fs)
+	force bool   // Opción -force (formatea aunque la partición este en uso)
+	ratio int32  // Cantidad de bloques por cada inodo
+	block int32  // Tamaño de los bloques en bytes (64, 128, 256 o 512)
 }
 
 /*
    mkfs -id=vd1 -type=full
    mkfs -id=vd2
+   mkfs -id=vd3 -fs=3fs
+   mkfs -id=vd1 -type=fast -force
+   mkfs -id=vd2 -blocksize=128 -ratio=4
 */
 
 func ParseMkfs(tokens []string) (*MKFS, error) {
@@ -29,7 +38,7 @@ func ParseMkfs(tokens []string) (*MKFS, error) {
 	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
 	args := strings.Join(tokens, " ")
 	// Expresión regular para encontrar los parámetros del comando mkfs
-	re := regexp.MustCompile(`-(?i:id=[^\s]+|type=[^\s]+)`)
+	re := regexp.MustCompile(`-(?i:id=[^\s]+|type=[^\s]+|fs=[^\s]+|ratio=[^\s]+|blocksize=[^\s]+|force)`)
 	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
 	matches := re.FindAllString(args, -1)
 
@@ -37,10 +46,15 @@ func ParseMkfs(tokens []string) (*MKFS, error) {
 	for _, match := range matches {
 		// Divide cada parte en clave y valor usando "=" como delimitador
 		kv := strings.SplitN(match, "=", 2)
+		key := strings.ToLower(kv[0])
+		if key == "-force" {
+			cmd.force = true
+			continue
+		}
 		if len(kv) != 2 {
 			return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
 		}
-		key, value := strings.ToLower(kv[0]), kv[1]
+		value := kv[1]
 
 		// Remove quotes from value if present
 		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
@@ -58,11 +72,33 @@ func ParseMkfs(tokens []string) (*MKFS, error) {
 			//fmt.Println(value)
 			cmd.id = value
 		case "-type":
-			// Verifica que el tipo sea "full"
-			if value != "full" {
-				return nil, errors.New("el tipo debe ser full")
+			// Verifica que el tipo sea "full" o "fast"
+			value = strings.ToLower(value)
+			if value != "full" && value != "fast" {
+				return nil, errors.New("el tipo debe ser full o fast")
 			}
 			cmd.typ = value
+		case "-fs":
+			// Verifica que el sistema de archivos sea 2fs o 3fs
+			value = strings.ToLower(value)
+			if value != "2fs" && value != "3fs" {
+				return nil, errors.New("el sistema de archivos debe ser 2fs o 3fs")
+			}
+			cmd.fs = value
+		case "-ratio":
+			// Verifica que la cantidad de bloques por inodo sea un número positivo
+			ratio, err := strconv.Atoi(value)
+			if err != nil || ratio <= 0 {
+				return nil, errors.New("el ratio debe ser un número entero positivo")
+			}
+			cmd.ratio = int32(ratio)
+		case "-blocksize":
+			// Verifica que el tamaño de bloque sea 64, 128, 256 o 512
+			size, err := strconv.Atoi(value)
+			if err != nil || (size != 64 && size != 128 && size != 256 && size != 512) {
+				return nil, errors.New("el tamaño de bloque debe ser 64, 128, 256 o 512")
+			}
+			cmd.block = int32(size)
 		default:
 			// Si el parámetro no es reconocido, devuelve un error
 			return nil, fmt.Errorf("parámetro desconocido: %s", key)
@@ -79,6 +115,21 @@ func ParseMkfs(tokens []string) (*MKFS, error) {
 		cmd.typ = "full"
 	}
 
+	// Si no se proporcionó el sistema de archivos, se establece por defecto a "2fs"
+	if cmd.fs == "" {
+		cmd.fs = "2fs"
+	}
+
+	// Si no se proporcionó el ratio, se usan 3 bloques por inodo
+	if cmd.ratio == 0 {
+		cmd.ratio = 3
+	}
+
+	// Si no se proporcionó el tamaño de bloque, se establece por defecto a 64 bytes
+	if cmd.block == 0 {
+		cmd.block = 64
+	}
+
 	// Aquí se puede agregar la lógica para ejecutar el comando mkfs con los parámetros proporcionados
 	err := commandMkfs(cmd)
 	if err != nil {
@@ -86,9 +137,10 @@ func ParseMkfs(tokens []string) (*MKFS, error) {
 		return nil, err
 	}
 
-	return cmd, fmt.Errorf("estructura ext2 generada: %+v", *cmd) // Devuelve el comando MKFS creado
+	return cmd, fmt.Errorf("estructura ext%c generada: %+v", cmd.fs[0], *cmd) // Devuelve el comando MKFS creado
 }
 
+// full llena de ceros toda la partición, fast solo reescribe el superbloque, el journal y los bitmaps
 func commandMkfs(mkfs *MKFS) error {
 	// Obtener la partición montada
 	mountedPartition, partitionPath, err := stores.GetMountedPartition(mkfs.id)
@@ -96,23 +148,47 @@ func commandMkfs(mkfs *MKFS) error {
 		return err
 	}
 
+	// No se formatea la partición de la sesión activa a menos que se indique -force
+	if ParticionEnUso(mkfs.id) && !mkfs.force {
+		return fmt.Errorf("error la partición %s esta en uso por el usuario logeado, utilice -force para formatearla", mkfs.id)
+	}
+
+	// El formato completo elimina todo el contenido anterior de la partición
+	if mkfs.typ == "full" {
+		err = utils.ZeroFillRange(partitionPath, int64(mountedPartition.Part_start), int64(mountedPartition.Part_size))
+		if err != nil {
+			return fmt.Errorf("error al limpiar la partición: %w", err)
+		}
+	}
+
 	// Verificar la partición montada
 	//fmt.Println("\nPatición montada:")
 	//mountedPartition.PrintPartition()
 
 	// Calcular el valor de n
-	n := calculateN(mountedPartition)
+	n := calculateN(mountedPartition, mkfs.fs, mkfs.ratio, mkfs.block)
+	if n < 2 {
+		return errors.New("error la partición es demasiado pequeña para el tamaño de bloque y el ratio indicados")
+	}
 
 	// Verificar el valor de n
 	//fmt.Println("\nValor de n:", n)
 
 	// Inicializar un nuevo superbloque
-	superBlock := createSuperBlock(mountedPartition, n)
+	superBlock := createSuperBlock(mountedPartition, n, mkfs.fs, mkfs.ratio, mkfs.block)
 
 	// Verificar el superbloque
 	//fmt.Println("\nSuperBlock:")
 	//superBlock.Print()
 
+	// En ext3 el journal va entre el superbloque y los bitmaps
+	if superBlock.IsExt3() {
+		err = superBlock.CreateJournal(partitionPath)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Crear los bitmaps
 	err = superBlock.CreateBitMaps(partitionPath)
 	if err != nil {
@@ -135,53 +211,68 @@ func commandMkfs(mkfs *MKFS) error {
 		return err
 	}
 
+	// La sesión que usaba la partición ya no es valida con el nuevo users.txt
+	LogoutParticion(mkfs.id)
+
 	return nil
 }
 
 // Calculo de inodos
-func calculateN(partition *structures.PARTITION) int32 {
+func calculateN(partition *structures.PARTITION, fs string, ratio int32, blockSize int32) int32 {
 	/*
 		numerador = (partition_montada.size - sizeof(Structs::Superblock)
-		denominador base = (4 + sizeof(Structs::Inodes) + 3 * sizeof(Structs::Fileblock))
+		denominador base = (1 + ratio + sizeof(Structs::Inodes) + ratio * block_size)
+		en ext3 el denominador tambien lleva sizeof(Structs::Journal)
 		n = floor(numerador / denominador)
 	*/
 
 	numerator := int(partition.Part_size) - binary.Size(structures.SuperBlock{})
-	denominator := 4 + binary.Size(structures.Inode{}) + 3*binary.Size(structures.FileBlock{}) // No importa que bloque poner, ya que todos tienen el mismo tamaño
+	denominator := 1 + int(ratio) + binary.Size(structures.Inode{}) + int(ratio)*int(blockSize) // Todos los bloques tienen el mismo tamaño
+	if fs == "3fs" {
+		denominator += binary.Size(structures.Journal{})
+	}
 	n := math.Floor(float64(numerator) / float64(denominator))
 
 	return int32(n)
 }
 
-func createSuperBlock(partition *structures.PARTITION, n int32) *structures.SuperBlock {
+func createSuperBlock(partition *structures.PARTITION, n int32, fs string, ratio int32, blockSize int32) *structures.SuperBlock {
 	// Calcular punteros de las estructuras
+	// Journal, solo en ext3 y con una entrada por inodo
+	filesystemType := int32(2)
+	journal_size := int32(0)
+	if fs == "3fs" {
+		filesystemType = 3
+		journal_size = int32(binary.Size(structures.Journal{})) * n
+	}
 	// Bitmaps
-	bm_inode_start := partition.Part_start + int32(binary.Size(structures.SuperBlock{}))
+	bm_inode_start := partition.Part_start + int32(binary.Size(structures.SuperBlock{})) + journal_size
 	bm_block_start := bm_inode_start + n // n indica la cantidad de inodos, solo la cantidad para ser representada en un bitmap
 	// Inodos
-	inode_start := bm_block_start + (3 * n) // 3*n indica la cantidad de bloques, se multiplica por 3 porque se tienen 3 tipos de bloques
+	inode_start := bm_block_start + (ratio * n) // ratio*n indica la cantidad de bloques, ratio bloques por cada inodo
 	// Bloques
 	block_start := inode_start + (int32(binary.Size(structures.Inode{})) * n) // n indica la cantidad de inodos, solo que aquí indica la cantidad de estructuras Inode
 
 	// Crear un nuevo superbloque
 	superBlock := &structures.SuperBlock{
-		S_filesystem_type:   2,
+		S_filesystem_type:   filesystemType,
 		S_inodes_count:      0,
 		S_blocks_count:      0,
 		S_free_inodes_count: int32(n),
-		S_free_blocks_count: int32(n * 3),
-		S_mtime:             float32(time.Now().Unix()),
-		S_umtime:            float32(time.Now().Unix()),
+		S_free_blocks_count: int32(n * ratio),
+		S_mtime:             time.Now().Unix(),
+		S_umtime:            time.Now().Unix(),
 		S_mnt_count:         1,
 		S_magic:             0xEF53,
 		S_inode_size:        int32(binary.Size(structures.Inode{})),
-		S_block_size:        int32(binary.Size(structures.FileBlock{})),
+		S_block_size:        blockSize,
 		S_first_ino:         inode_start,
 		S_first_blo:         block_start,
 		S_bm_inode_start:    bm_inode_start,
 		S_bm_block_start:    bm_block_start,
 		S_inode_start:       inode_start,
 		S_block_start:       block_start,
+		S_version:           structures.FormatVersion,
 	}
 	return superBlock
 }
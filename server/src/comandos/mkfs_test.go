@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	stores "bakend/src/almacenamiento"
+	structures "bakend/src/estructuras"
+)
+
+// Crea un archivo con el marcador en la raíz de la partición montada
+func writeMarker(t *testing.T, id string, marcador string) {
+	t.Helper()
+
+	loginRoot(t, id)
+	origen := filepath.Join(t.TempDir(), "marcador.txt")
+	err := os.WriteFile(origen, []byte(marcador), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = commandMkfile(&MKFILE{path: "/marcador.txt", cont: origen})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Valida con el fsck la partición recién formateada y que el archivo del marcador no exista
+func assertFormatted(t *testing.T, id string) {
+	t.Helper()
+
+	sb, _, path, err := stores.GetMountedPartitionSuperblock(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = sb.ResolvePath(path, []string{"marcador.txt"}, structures.RootUID, structures.RootUID)
+	if err == nil {
+		t.Error("el archivo del marcador no debe de existir despues del formato")
+	}
+	issues, err := sb.CheckFilesystem(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range issues {
+		t.Errorf("[%s] %s", issue.Code, issue.Message)
+	}
+}
+
+func TestMkfsFastKeepsOldDataAndFullZeroesIt(t *testing.T) {
+	id, path := newTestPartition(t)
+	marcador := []byte("contenido_anterior_al_formato")
+	err := commandMkfs(&MKFS{id: id, typ: "full", fs: "2fs", ratio: 3, block: 64})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeMarker(t, id, string(marcador))
+
+	// La partición de la sesión activa solo se formatea con -force
+	err = commandMkfs(&MKFS{id: id, typ: "fast", fs: "2fs", ratio: 3, block: 64})
+	if err == nil {
+		t.Fatal("se esperaba un error al formatear la partición en uso sin -force")
+	}
+	if !ObtenerLogin() {
+		t.Error("la sesión no debe de cerrarse si el formato fue rechazado")
+	}
+
+	// El formato rápido solo reescribe el superbloque y los bitmaps
+	err = commandMkfs(&MKFS{id: id, typ: "fast", fs: "2fs", ratio: 3, block: 64, force: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ObtenerLogin() {
+		t.Error("la sesión de la partición formateada debe de cerrarse")
+	}
+	disco, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(disco, marcador) {
+		t.Error("el formato rápido no debe de limpiar los bloques anteriores")
+	}
+	assertFormatted(t, id)
+
+	// El formato completo limpia toda la partición antes de crear las estructuras
+	writeMarker(t, id, string(marcador))
+	err = commandMkfs(&MKFS{id: id, typ: "full", fs: "3fs", ratio: 3, block: 64, force: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	disco, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(disco, marcador) {
+		t.Error("el formato completo debe de limpiar los datos anteriores")
+	}
+	assertFormatted(t, id)
+}
@@ -67,12 +67,7 @@ func ParseMkgrp(tokens []string) (*MKGRP, error) {
 	return cmd, fmt.Errorf("grupo de usuarios creado: %+v", *cmd)
 }
 
-var respaldoId2 = ""
-var tipo2 = ""
-
 func commandMkgrp(comando *MKGRP) error {
-	respaldoId2 = ""
-	tipo2 = ""
 	//Obtenemos el usuario logeado
 	var usuario = ObtenerUsuari()
 
@@ -96,225 +91,44 @@ func commandMkgrp(comando *MKGRP) error {
 		return fmt.Errorf("error al intenter escribir en el user.txt: %w", err2)
 	}
 
-	return nil
+	// Registrar la operación en el journal (solo en ext3)
+	return registrarJournal(partitionSuperblock, partitionPath, "mkgrp", "/users.txt", comando.name, usuario)
 }
 
 // Funcion para accder al archivo de user.txt
 // CrearUser: path del disco, objeto con los datos del usuario, el inicio de los inodos
 func MkgprComand(path string, login *LOGIN, comando *MKGRP, inodeIndex int32, sb *structures.SuperBlock, mountedPartition *structures.PARTITION) error {
-	//Se crea una instancia de un objeto de tipo Inode
-	inode := &structures.Inode{}
-
-	// Deserializar el inodo
-	err := inode.Deserialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
+	//Obtengo el texto completo del archivo users.txt
+	inode, lines, err := leerUsersTxt(path, inodeIndex, sb)
 	if err != nil {
 		return err
 	}
 
-	//Aca se almacenara el contenido de user.txt
-	data := ""
-	// Crear un nuevo bloque de archivo
-	block := &structures.FileBlock{}
-	indiceFinal := 0
-	// Iterar sobre cada bloque del inodo (apuntadores)
-	for indiceList, blockIndex := range inode.I_block {
-		// Si el bloque no existe, salir
-		if blockIndex == -1 {
-			break
-		}
-		indiceFinal = int(blockIndex)
-		// Crear un nuevo bloque de archivo
-		block = &structures.FileBlock{}
-
-		// Deserializar el bloque desde el incio de los blokes  + posicion por el peso de los bloques  que es 64
-		err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size))) // 64 porque es el tamaño de un bloque
-		if err != nil {
-			return err
-		}
-
-		//Obtengo el texto del archivo uset.txt, de un fileblock
-		data = strings.Trim(string(block.B_content[:]), "\x00 ")
-
-		/*
-			1,G,root
-			1,U,root,root,123
-		*/
-		// Dividir por salto de línea
-		lines := strings.Split(data, "\n")
-		// Recorrer cada línea y dividir por comas
-		var id = ""
-		var nombre = ""
-
-		//Esto es para obtner los datos puntuales
-		for _, line := range lines {
-			values := strings.Split(line, ",")
-
-			// Almacenar en variables según la cantidad de datos
-			//Esto son los grupos
-			if len(values) == 3 {
-				id, tipo2, nombre = values[0], values[1], values[2]
-				//Esto quiere decir que el grupo esta elimando por lo tanto guradomos el correcto
-				if id != "0" {
-					respaldoId2 = id
-				}
-				if nombre == comando.name && id != "0" {
-					return fmt.Errorf("error ya existe otro grupo: %s", nombre)
-				}
-				//fmt.Printf("ID: %s, Tipo: %s, Nombre: %s\n", id, tipo, nombre)
-			}
-		}
-
-		//Esto solo es para comvertirlo a numero
-		num, err := strconv.Atoi(respaldoId2)
-		if err != nil {
-			fmt.Println("Error:", err)
-			return err
-		}
-
-		//Sumamos uno al grupo ya existente
-		int32Num := int32(num)
-		int32Num += 1
-		nuevoUsuario := strconv.Itoa(int(int32Num)) + "," + tipo2 + "," + comando.name + "\n"
-
-		//Aca debo de validar que el indice no se salga de 14
-		if indiceList+1 == 15 {
-			//Aca si llegamos al ultimo inodo y esta llena el FileBlock
-			if len(data) >= 64 {
-				return errors.New("ya no existe espacio para crear mas grupos")
-				//Aca valido si la suma del nuevo grupo supera los 64 bytes
-			} else if (len(data) + len(nuevoUsuario)) >= 64 {
-				return errors.New("ya no existe espacio en el ultimo bloque para crear mas grupos")
-				//Aca se agrega el nuevo grupo
-			} else {
-				//Unicamente se debe de agergar el grupo y detener el programa
-				//Se agrega a la cadena principal
-				data += nuevoUsuario
-
-				//Sustituir los datos anteriores por los nuevos
-				// Copiamos el texto de usuarios en el bloque
-				copy(block.B_content[:], data)
-
-				//Se serealiza todo el contenido en el Fileblock
-				err2 := block.Serialize(path, int64(sb.S_block_start+(int32(indiceFinal)*sb.S_block_size)))
-				if err2 != nil {
-					return err2
-				}
-				break
-			}
-		}
-
-		//validar si el bloque esta lleno ó si la suma del nuevo texto supera el espacio de 64 bytes
-		if len(data) >= 64 {
-			//Aca el sigueinte bloque esta basillo
-			if inode.I_block[indiceList+1] == -1 {
-				//Aca se debe de generar un nuevo FileBlock
-				//TODO: pendiente
-				//Primero se actualiza el ibloque
-				inode.I_block[indiceList+1] = blockIndex + 1
-				//Serealizar el ibloque actualizado
-				// Deserializar el inodo
-				err := inode.Serialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
-				if err != nil {
-					return err
-				}
-				//Generamos el nuevo Filebloque
-				nuevoFilebloque := &structures.FileBlock{}
-				// Copiamos el texto de usuarios en el bloque
-				copy(nuevoFilebloque.B_content[:], nuevoUsuario)
-				//Se serealiza todo el contenido en el Fileblock
-				//					inicio de la tabla de bloques + (indice +1* temaño del bloque)
-				err2 := nuevoFilebloque.Serialize(path, int64(sb.S_block_start+(int32(indiceFinal+1)*sb.S_block_size)))
-				if err2 != nil {
-					return err2
-				}
-				// Actualizar el bitmap de bloques
-				err = sb.UpdateBitmapBlock(path)
-				if err != nil {
-					return err
-				}
-				//Se actualiza el superbloque
-				sb.S_blocks_count++
-				sb.S_free_blocks_count--
-				sb.S_first_blo += sb.S_block_size
-
-				// Serializar el superbloque
-				err = sb.Serialize(path, int64(mountedPartition.Part_start))
-				if err != nil {
-					return fmt.Errorf("error al serializar el superbloque: %w", err)
-				}
-				// fmt.Println("**********")
-				// nuevoFilebloque.Print()
-				break
-			} else {
-				//Aca necesito avanzar al sigueinte bloque
+	//Aca se guarda el ultimo id de grupo que no esta eliminado
+	respaldoId := "0"
+	for _, line := range lines {
+		values := strings.Split(line, ",")
+
+		//Esto son los grupos
+		if len(values) == 3 {
+			id, nombre := values[0], values[2]
+			//Si el grupo esta elimando no se toma en cuenta su id
+			if id != "0" {
+				respaldoId = id
 			}
-		} else if (len(data) + len(nuevoUsuario)) >= 64 {
-			//Validamos si el nuevo usuario mas el texto anterior no se pase de 64 bytes
-			//Validamos que el sigueinte iblock esta basillo y si si lo creamos
-			if inode.I_block[indiceList+1] == -1 {
-				//Aca se debe de generar un nuevo FileBlock
-				//TODO: pendiente
-				//Primero se actualiza el ibloque
-				inode.I_block[indiceList+1] = blockIndex + 1
-				//Serealizar el ibloque actualizado
-				// Deserializar el inodo
-				err := inode.Serialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
-				if err != nil {
-					return err
-				}
-				//Generamos el nuevo Filebloque
-				nuevoFilebloque := &structures.FileBlock{}
-				// Copiamos el texto de usuarios en el bloque
-				copy(nuevoFilebloque.B_content[:], nuevoUsuario)
-				//Se serealiza todo el contenido en el Fileblock
-				//					inicio de la tabla de bloques + (indice +1* temaño del bloque)
-				err2 := nuevoFilebloque.Serialize(path, int64(sb.S_block_start+(int32(indiceFinal+1)*sb.S_block_size)))
-				if err2 != nil {
-					return err2
-				}
-				// Actualizar el bitmap de bloques
-				err = sb.UpdateBitmapBlock(path)
-				if err != nil {
-					return err
-				}
-				//Se actualiza el superbloque
-				sb.S_blocks_count++
-				sb.S_free_blocks_count--
-				sb.S_first_blo += sb.S_block_size
-
-				// Serializar el superbloque
-				err = sb.Serialize(path, int64(mountedPartition.Part_start))
-				if err != nil {
-					return fmt.Errorf("error al serializar el superbloque: %w", err)
-				}
-				// fmt.Println("**********")
-				// nuevoFilebloque.Print()
-			} else {
-				//Aca necesito avanzar al sigueinte bloque
+			if nombre == comando.name && id != "0" {
+				return fmt.Errorf("error ya existe otro grupo: %s", nombre)
 			}
-		} else {
-			//Aca quiere decir que ya puedo agregar el nuevo grupo al fileblock y finalizo el bucle
-			//Se agrega a la cadena principal
-			data += nuevoUsuario
-
-			//Sustituir los datos anteriores por los nuevos
-			// Copiamos el texto de usuarios en el bloque
-			copy(block.B_content[:], data)
-
-			//Se serealiza todo el contenido en el Fileblock
-			err2 := block.Serialize(path, int64(sb.S_block_start+(int32(indiceFinal)*sb.S_block_size)))
-			if err2 != nil {
-				return err2
-			}
-
-			// fmt.Println("**********")
-			// block.Print()
-			break
 		}
-
 	}
 
-	//block.Print()
+	//Esto solo es para comvertirlo a numero
+	num, err := strconv.Atoi(respaldoId)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	//Sumamos uno al grupo ya existente, el archivo crece con los bloques indirectos si hace falta
+	lines = append(lines, strconv.Itoa(num+1)+",G,"+comando.name)
+	return escribirUsersTxt(path, inodeIndex, inode, lines, sb, mountedPartition)
 }
@@ -87,14 +87,8 @@ func ParseMkusr(tokens []string) (*MKUSR, error) {
 	return cmd, fmt.Errorf("usuario creado exitosamente: %+v", *cmd)
 }
 
-// Para respaldar el identificador
-var respaldoId = ""
-var tipo = ""
-
 // Esto es para obtener el superbloque
 func commandMkusr(comando *MKUSR) error {
-	respaldoId = ""
-	tipo = ""
 	//Obtenemos el usuario logeado
 	var usuario = ObtenerUsuari()
 
@@ -118,240 +112,45 @@ func commandMkusr(comando *MKUSR) error {
 		return fmt.Errorf("error al intenter escribir en el user.txt: %w", err2)
 	}
 
-	return nil
+	// Registrar la operación en el journal (solo en ext3)
+	return registrarJournal(partitionSuperblock, partitionPath, "mkusr", "/users.txt", comando.user+","+comando.pass+","+comando.grp, usuario)
 }
 
 // Funcion para accder al archivo de user.txt
 // CrearUser: path del disco, objeto con los datos del usuario, el inicio de los inodos
 func MkusrComand(path string, login *LOGIN, comando *MKUSR, inodeIndex int32, sb *structures.SuperBlock, mountedPartition *structures.PARTITION) error {
-	//Se crea una instancia de un objeto de tipo Inode
-	inode := &structures.Inode{}
-
-	// Deserializar el inodo
-	err := inode.Deserialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
+	//Obtengo el texto completo del archivo users.txt
+	inode, lines, err := leerUsersTxt(path, inodeIndex, sb)
 	if err != nil {
 		return err
 	}
 
-	//Aca se almacenara el contenido de user.txt
-	data := ""
-	// Crear un nuevo bloque de archivo
-	block := &structures.FileBlock{}
-	indiceFinal := 0
-	// Iterar sobre cada bloque del inodo (apuntadores)
-	for indiceList, blockIndex := range inode.I_block {
-		// Si el bloque no existe, salir
-		if blockIndex == -1 {
-			break
-		}
-		indiceFinal = int(blockIndex)
-		// Crear un nuevo bloque de archivo
-		block = &structures.FileBlock{}
-
-		// Deserializar el bloque desde el incio de los blokes  + posicion por el peso de los bloques  que es 64
-		err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size))) // 64 porque es el tamaño de un bloque
-		if err != nil {
-			return err
-		}
-
-		//Obtengo el texto del archivo uset.txt, de un fileblock
-		data = strings.Trim(string(block.B_content[:]), "\x00 ")
-
-		/*
-			1,G,root
-			1,U,root,root,123
-		*/
-		// Dividir por salto de línea
-		lines := strings.Split(data, "\n")
-		// Recorrer cada línea y dividir por comas
-		var id = ""
-		// var grupo = ""
-		var usuario = ""
-		// var password = ""
-
-		// //Para validar si existe el grupo
-		// var existeGrupo = true
-		//Esto es para obtner los datos puntuales
-		for _, line := range lines {
-			values := strings.Split(line, ",")
-
-			// Almacenar en variables según la cantidad de datos
-			//Esto son los grupos
-			if len(values) == 5 {
-				//Estos son los usuarios
-				id, tipo, _, usuario, _ = values[0], values[1], values[2], values[3], values[4]
-				//Esto quiere decir que el grupo esta elimando por lo tanto guradomos el correcto
-				if id != "0" {
-					respaldoId = id
-				}
-				// //Esto valida que exista el grupo
-				// if grupo == comando.grp {
-				// 	existeGrupo = false
-				// }
-				//Aca se valida si el usuario ya esta pero tambien si no esta eliminado
-				if usuario == comando.user && id != "0" {
-					return fmt.Errorf("error ya existe otro usuario: %s", usuario)
-				}
-				//fmt.Printf("ID: %s, Tipo: %s, Nombre: %s\n", id, tipo, nombre)
-			}
-
-		}
-
-		// if existeGrupo {
-		// 	return fmt.Errorf("error no existe el grupo: %s", comando.grp)
-		// }
-
-		//Esto solo es para comvertirlo a numero
-		num, err := strconv.Atoi(respaldoId)
-		if err != nil {
-			fmt.Println("Error:", err)
-			return err
-		}
-
-		//Sumamos uno al grupo ya existente
-		int32Num := int32(num)
-		int32Num += 1
-		nuevoUsuario := strconv.Itoa(int(int32Num)) + "," + tipo + "," + comando.grp + "," + comando.user + "," + comando.pass + "\n"
-
-		//Aca debo de validar que el indice no se salga de 14
-		if indiceList+1 == 15 {
-			//Aca si llegamos al ultimo inodo y esta llena el FileBlock
-			if len(data) >= 64 {
-				return errors.New("ya no existe espacio para crear mas grupos")
-				//Aca valido si la suma del nuevo grupo supera los 64 bytes
-			} else if (len(data) + len(nuevoUsuario)) >= 64 {
-				return errors.New("ya no existe espacio en el ultimo bloque para crear mas grupos")
-				//Aca se agrega el nuevo grupo
-			} else {
-				//Unicamente se debe de agergar el grupo y detener el programa
-				//Se agrega a la cadena principal
-				data += nuevoUsuario
-
-				//Sustituir los datos anteriores por los nuevos
-				// Copiamos el texto de usuarios en el bloque
-				copy(block.B_content[:], data)
-
-				//Se serealiza todo el contenido en el Fileblock
-				err2 := block.Serialize(path, int64(sb.S_block_start+(int32(indiceFinal)*sb.S_block_size)))
-				if err2 != nil {
-					return err2
-				}
-
-				break
-			}
-		}
-
-		//validar si el bloque esta lleno ó si la suma del nuevo texto supera el espacio de 64 bytes
-		if len(data) >= 64 {
-			//Aca el sigueinte bloque esta basillo
-			if inode.I_block[indiceList+1] == -1 {
-				//Aca se debe de generar un nuevo FileBlock
-				//TODO: pendiente
-				//Primero se actualiza el ibloque
-				inode.I_block[indiceList+1] = blockIndex + 1
-				//Serealizar el ibloque actualizado
-				// Deserializar el inodo
-				err := inode.Serialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
-				if err != nil {
-					return err
-				}
-				//Generamos el nuevo Filebloque
-				nuevoFilebloque := &structures.FileBlock{}
-				// Copiamos el texto de usuarios en el bloque
-				copy(nuevoFilebloque.B_content[:], nuevoUsuario)
-				//Se serealiza todo el contenido en el Fileblock
-				//					inicio de la tabla de bloques + (indice +1* temaño del bloque)
-				err2 := nuevoFilebloque.Serialize(path, int64(sb.S_block_start+(int32(indiceFinal+1)*sb.S_block_size)))
-				if err2 != nil {
-					return err2
-				}
-				// Actualizar el bitmap de bloques
-				err = sb.UpdateBitmapBlock(path)
-				if err != nil {
-					return err
-				}
-				//Se actualiza el superbloque
-				sb.S_blocks_count++
-				sb.S_free_blocks_count--
-				sb.S_first_blo += sb.S_block_size
-
-				// Serializar el superbloque
-				err = sb.Serialize(path, int64(mountedPartition.Part_start))
-				if err != nil {
-					return fmt.Errorf("error al serializar el superbloque: %w", err)
-				}
-				// fmt.Println("**********")
-				// nuevoFilebloque.Print()
-				break
-			} else {
-				//Aca necesito avanzar al sigueinte bloque
+	//Aca se guarda el ultimo id de usuario que no esta eliminado
+	respaldoId := "0"
+	for _, line := range lines {
+		values := strings.Split(line, ",")
+
+		//Estos son los usuarios
+		if len(values) == 5 {
+			id, usuario := values[0], values[3]
+			//Si el usuario esta elimando no se toma en cuenta su id
+			if id != "0" {
+				respaldoId = id
 			}
-		} else if (len(data) + len(nuevoUsuario)) >= 64 {
-			//Validamos si el nuevo usuario mas el texto anterior no se pase de 64 bytes
-			//Validamos que el sigueinte iblock esta basillo y si si lo creamos
-			if inode.I_block[indiceList+1] == -1 {
-				//Aca se debe de generar un nuevo FileBlock
-				//TODO: pendiente
-				//Primero se actualiza el ibloque
-				inode.I_block[indiceList+1] = blockIndex + 1
-				//Serealizar el ibloque actualizado
-				// Deserializar el inodo
-				err := inode.Serialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
-				if err != nil {
-					return err
-				}
-				//Generamos el nuevo Filebloque
-				nuevoFilebloque := &structures.FileBlock{}
-				// Copiamos el texto de usuarios en el bloque
-				copy(nuevoFilebloque.B_content[:], nuevoUsuario)
-				//Se serealiza todo el contenido en el Fileblock
-				//					inicio de la tabla de bloques + (indice +1* temaño del bloque)
-				err2 := nuevoFilebloque.Serialize(path, int64(sb.S_block_start+(int32(indiceFinal+1)*sb.S_block_size)))
-				if err2 != nil {
-					return err2
-				}
-				// Actualizar el bitmap de bloques
-				err = sb.UpdateBitmapBlock(path)
-				if err != nil {
-					return err
-				}
-				//Se actualiza el superbloque
-				sb.S_blocks_count++
-				sb.S_free_blocks_count--
-				sb.S_first_blo += sb.S_block_size
-
-				// Serializar el superbloque
-				err = sb.Serialize(path, int64(mountedPartition.Part_start))
-				if err != nil {
-					return fmt.Errorf("error al serializar el superbloque: %w", err)
-				}
-				// fmt.Println("**********")
-				// nuevoFilebloque.Print()
-			} else {
-				//Aca necesito avanzar al sigueinte bloque
-			}
-		} else {
-			//Aca quiere decir que ya puedo agregar el nuevo grupo al fileblock y finalizo el bucle
-			//Se agrega a la cadena principal
-			data += nuevoUsuario
-
-			//Sustituir los datos anteriores por los nuevos
-			// Copiamos el texto de usuarios en el bloque
-			copy(block.B_content[:], data)
-
-			//Se serealiza todo el contenido en el Fileblock
-			err2 := block.Serialize(path, int64(sb.S_block_start+(int32(indiceFinal)*sb.S_block_size)))
-			if err2 != nil {
-				return err2
+			//Aca se valida si el usuario ya esta pero tambien si no esta eliminado
+			if usuario == comando.user && id != "0" {
+				return fmt.Errorf("error ya existe otro usuario: %s", usuario)
 			}
-			// fmt.Println("**********")
-			// block.Print()
-			break
 		}
-
 	}
 
-	//block.Print()
+	//Esto solo es para comvertirlo a numero
+	num, err := strconv.Atoi(respaldoId)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	//Sumamos uno al usuario ya existente, el archivo crece con los bloques indirectos si hace falta
+	lines = append(lines, strconv.Itoa(num+1)+",U,"+comando.grp+","+comando.user+","+comando.pass)
+	return escribirUsersTxt(path, inodeIndex, inode, lines, sb, mountedPartition)
 }
@@ -99,10 +99,17 @@ func commandMount(mount *MOUNT) error {
 	}
 
 	// Buscar la partición con el nombre especificado
-	partition, indexPartition := mbr.GetPartitionByName(mount.name)
+	partition, indexPartition := mbr.GetPartitionIndexByName(mount.name)
 	if partition == nil {
-		//fmt.Println("Error: la partición no existe")
-		return errors.New("error en el mount: la partición no existe o ya esta montada")
+		// Si no esta en el MBR se busca entre las particiones lógicas
+		return mountLogicPartition(mount, &mbr)
+	}
+	if partition.Part_type[0] == 'E' {
+		return errors.New("error en el mount: no se puede montar una partición extendida")
+	}
+	// Si el MBR la marca como montada pero no tiene id se vuelve a montar con un id nuevo
+	if partition.Part_status[0] == '1' && stores.IsPartitionMounted(mount.path, partition.GetName()) {
+		return errors.New("error en el mount: la partición ya esta montada")
 	}
 
 	/* SOLO PARA VERIFICACIÓN */
@@ -119,6 +126,7 @@ func commandMount(mount *MOUNT) error {
 
 	//  Guardar la partición montada en la lista de montajes globales
 	stores.MountedPartitions[idPartition] = mount.path
+	stores.MountedNames[idPartition] = partition.GetName()
 
 	// Modificamos la partición para indicar que está montada
 	partition.MountPartition(partitionCorrelative, idPartition)
@@ -138,6 +146,60 @@ func commandMount(mount *MOUNT) error {
 		return err
 	}
 
+	// Guardar la tabla de montajes para no perderla al reiniciar el servidor
+	err = stores.SaveMountState()
+	if err != nil {
+		return fmt.Errorf("error guardando el estado de los montajes: %w", err)
+	}
+
+	return nil
+}
+
+// Monta una partición lógica, el estado de montaje queda guardado en su EBR
+func mountLogicPartition(mount *MOUNT, mbr *structures.MBR) error {
+	extendida := mbr.GetExtendedPartition2()
+	if extendida == nil {
+		return errors.New("error en el mount: la partición no existe")
+	}
+
+	// Buscar la partición lógica dentro de la cadena de EBRs
+	cadena, err := structures.GetEBRChain(mount.path, extendida.Part_start)
+	if err != nil {
+		return err
+	}
+	logica := structures.GetLogicalByName(cadena, mount.name)
+	if logica == nil {
+		return errors.New("error en el mount: la partición no existe")
+	}
+	// Si el EBR la marca como montada pero no tiene id se vuelve a montar con un id nuevo
+	if logica.Ebr_mount[0] == '1' && stores.IsPartitionMounted(mount.path, logica.GetName()) {
+		return errors.New("error en el mount: la partición ya esta montada")
+	}
+
+	// Generar un id único para la partición
+	idPartition, _, err := generatePartitionID(mount)
+	if err != nil {
+		return err
+	}
+
+	// Marcar el EBR como montado
+	logica.Ebr_mount[0] = '1'
+	err = logica.SerializeEBR(mount.path, logica.Ebr_start)
+	if err != nil {
+		fmt.Println("Error serializando el EBR:", err)
+		return err
+	}
+
+	// Guardar la partición montada en la lista de montajes globales, la lógica se encuentra por su nombre
+	stores.MountedPartitions[idPartition] = mount.path
+	stores.MountedNames[idPartition] = logica.GetName()
+
+	// Guardar la tabla de montajes para no perderla al reiniciar el servidor
+	err = stores.SaveMountState()
+	if err != nil {
+		return fmt.Errorf("error guardando el estado de los montajes: %w", err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,104 @@
+package analyzer
+
+import (
+	stores "bakend/src/almacenamiento"
+	utils "bakend/src/utils"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MOVE estructura que representa el comando move con sus parámetros
+type MOVE struct {
+	path    string // Path del archivo o carpeta a mover
+	destino string // Carpeta a donde se mueve
+}
+
+/*
+   move -path=/home/user/docs/a.txt -destino=/home/images
+   move -path="/home/mis documentos" -destino=/respaldo
+*/
+
+func ParseMove(tokens []string) (*MOVE, error) {
+	cmd := &MOVE{} // Crea una nueva instancia de MOVE
+
+	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
+	args := strings.Join(tokens, " ")
+	// Expresión regular para encontrar los parámetros del comando move
+	re := regexp.MustCompile(`-(?i:path="[^"]+"|path=[^\s]+|destino="[^"]+"|destino=[^\s]+)`)
+	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
+	matches := re.FindAllString(args, -1)
+
+	// Itera sobre cada coincidencia encontrada
+	for _, match := range matches {
+		// Divide cada parte en clave y valor usando "=" como delimitador
+		kv := strings.SplitN(match, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+
+		// Remove comillas si estan present
+		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+			value = strings.Trim(value, "\"")
+		}
+
+		// Switch para manejar diferentes parámetros
+		switch key {
+		case "-path":
+			cmd.path = value
+		case "-destino":
+			cmd.destino = value
+		default:
+			// Si el parámetro no es reconocido, devuelve un error
+			return nil, fmt.Errorf("parámetro desconocido: %s", key)
+		}
+	}
+
+	// Verifica que los parámetros -path y -destino hayan sido proporcionados
+	if cmd.path == "" {
+		return nil, errors.New("faltan parámetros requeridos: -path")
+	}
+	if cmd.destino == "" {
+		return nil, errors.New("faltan parámetros requeridos: -destino")
+	}
+
+	err := commandMove(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd, fmt.Errorf("movido correctamente MOVE: %+v", *cmd)
+}
+
+// Se mueve con los permisos del usuario logeado
+func commandMove(move *MOVE) error {
+	//Obtenemos el usuario logeado
+	var usuario = ObtenerUsuari()
+
+	// Obtener la partición montada
+	partitionSuperblock, mountedPartition, partitionPath, err := stores.GetMountedPartitionSuperblock(usuario.id)
+	if err != nil {
+		return fmt.Errorf("error al obtener la partición montada: %w", err)
+	}
+
+	// GetParentDirectories obtiene las carpetas padres y el nombre a mover
+	parentDirs, nombre := utils.GetParentDirectories(move.path)
+	// La carpeta destino completa
+	destDirs, destDir := utils.GetParentDirectories(move.destino)
+
+	err = partitionSuperblock.MovePath(partitionPath, parentDirs, nombre, append(destDirs, destDir), usuario.uid, usuario.gid)
+	if err != nil {
+		return fmt.Errorf("error al mover: %w", err)
+	}
+
+	// Serializar el superbloque por si la carpeta destino necesito un bloque nuevo
+	err = partitionSuperblock.Serialize(partitionPath, int64(mountedPartition.Part_start))
+	if err != nil {
+		return fmt.Errorf("error al serializar el superbloque: %w", err)
+	}
+
+	// Registrar la operación en el journal (solo en ext3)
+	return registrarJournal(partitionSuperblock, partitionPath, "move", move.path, move.destino, usuario)
+}
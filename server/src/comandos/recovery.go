@@ -0,0 +1,96 @@
+package analyzer
+
+import (
+	stores "bakend/src/almacenamiento"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RECOVERY estructura que representa el comando recovery con sus parámetros
+type RECOVERY struct {
+	id string // ID de la partición montada
+}
+
+/*
+   recovery -id=271A
+*/
+
+func ParseRecovery(tokens []string) (*RECOVERY, error) {
+	cmd := &RECOVERY{} // Crea una nueva instancia de RECOVERY
+
+	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
+	args := strings.Join(tokens, " ")
+	// Expresión regular para encontrar los parámetros del comando recovery
+	re := regexp.MustCompile(`-(?i:id=[^\s]+)`)
+	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
+	matches := re.FindAllString(args, -1)
+
+	// Itera sobre cada coincidencia encontrada
+	for _, match := range matches {
+		// Divide cada parte en clave y valor usando "=" como delimitador
+		kv := strings.SplitN(match, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+
+		// Switch para manejar diferentes parámetros
+		switch key {
+		case "-id":
+			cmd.id = value
+		default:
+			// Si el parámetro no es reconocido, devuelve un error
+			return nil, fmt.Errorf("parámetro desconocido: %s", key)
+		}
+	}
+
+	// Verifica que el parámetro -id haya sido proporcionado
+	if cmd.id == "" {
+		return nil, errors.New("faltan parámetros requeridos: -id")
+	}
+
+	err := commandRecovery(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd, fmt.Errorf("sistema de archivos recuperado RECOVERY: %+v", *cmd)
+}
+
+// Formatea de nuevo la partición y ejecuta en orden las operaciones del journal
+func commandRecovery(recovery *RECOVERY) error {
+	// Obtener la partición montada
+	partitionSuperblock, mountedPartition, partitionPath, err := stores.GetMountedPartitionSuperblock(recovery.id)
+	if err != nil {
+		return fmt.Errorf("error al obtener la partición montada: %w", err)
+	}
+
+	// El journal se lee antes de formatear, el formato no lo modifica
+	entradas, err := partitionSuperblock.GetJournal(partitionPath)
+	if err != nil {
+		return err
+	}
+
+	err = partitionSuperblock.ResetFilesystem(partitionPath)
+	if err != nil {
+		return fmt.Errorf("error al formatear el sistema de archivos: %w", err)
+	}
+
+	var errReplay error
+	for i, entrada := range entradas {
+		errReplay = replayJournal(partitionPath, partitionSuperblock, mountedPartition, entrada)
+		if errReplay != nil {
+			errReplay = fmt.Errorf("error al recuperar la operación %d (%s %s): %w", i+1, entrada.Operacion, entrada.Ruta, errReplay)
+			break
+		}
+	}
+
+	// El superbloque se guarda aunque falle una operación para que quede consistente con los bitmaps
+	err = partitionSuperblock.Serialize(partitionPath, int64(mountedPartition.Part_start))
+	if err != nil {
+		return fmt.Errorf("error al serializar el superbloque: %w", err)
+	}
+	return errReplay
+}
@@ -0,0 +1,134 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	stores "bakend/src/almacenamiento"
+	structures "bakend/src/estructuras"
+)
+
+// Crea un disco con una partición primaria montada, retorna el id y la ruta del disco
+// El estado de los montajes se guarda en un directorio temporal
+func newTestPartition(t *testing.T) (string, string) {
+	t.Helper()
+
+	stores.MountStateFile = filepath.Join(t.TempDir(), "mount_state.json")
+	t.Cleanup(func() {
+		stores.ClearMountedPartitions()
+		SetearLogin()
+		cmd = &LOGIN{}
+	})
+
+	path := newTestDisk(t, "FF", []FDISK{{size: 60, unit: "K", fit: "FF", typ: "P", name: "Part1"}})
+	err := commandMount(&MOUNT{path: path, name: "Part1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, disco := range stores.MountedPartitions {
+		if disco == path {
+			return id, path
+		}
+	}
+	t.Fatal("no se encontro el id de la partición montada")
+	return "", ""
+}
+
+// Inicia sesión como root en la partición
+func loginRoot(t *testing.T, id string) {
+	t.Helper()
+
+	cmd = &LOGIN{user: "root", pass: "123", id: id}
+	err := commandLogear(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Retorna el contenido del archivo en la partición montada
+func readTestFile(t *testing.T, id string, components ...string) string {
+	t.Helper()
+
+	sb, _, path, err := stores.GetMountedPartitionSuperblock(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contenido, err := sb.GetFileContent(path, components[:len(components)-1], components[len(components)-1], structures.RootUID, structures.RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return contenido
+}
+
+func TestLossAndRecoveryRestoreTree(t *testing.T) {
+	id, _ := newTestPartition(t)
+	err := commandMkfs(&MKFS{id: id, typ: "full", fs: "3fs", ratio: 3, block: 64})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loginRoot(t, id)
+
+	err = commandMkdir(&MKDIR{path: "/home/docs", p: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = commandMkfile(&MKFILE{path: "/home/docs/a.txt", size: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = commandMkfile(&MKFILE{path: "/borrar.txt", size: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = commandRename(&RENAME{path: "/home/docs/a.txt", name: "b.txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = commandRemove(&REMOVE{path: "/borrar.txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	contenido := readTestFile(t, id, "home", "docs", "b.txt")
+
+	err = commandLoss(&LOSS{id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sb, _, path, err := stores.GetMountedPartitionSuperblock(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = sb.GetFileContent(path, []string{"home", "docs"}, "b.txt", structures.RootUID, structures.RootUID)
+	if err == nil {
+		t.Fatal("se esperaba un error al leer un archivo despues del loss")
+	}
+
+	err = commandRecovery(&RECOVERY{id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recuperado := readTestFile(t, id, "home", "docs", "b.txt"); recuperado != contenido {
+		t.Errorf("contenido recuperado %q, se esperaba %q", recuperado, contenido)
+	}
+	if usuarios := readTestFile(t, id, "users.txt"); usuarios != "1,G,root\n1,U,root,root,123\n" {
+		t.Errorf("users.txt recuperado %q", usuarios)
+	}
+
+	sb, _, path, err = stores.GetMountedPartitionSuperblock(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, borrado := range [][]string{{"borrar.txt"}, {"home", "docs", "a.txt"}} {
+		_, err = sb.ResolvePath(path, borrado, structures.RootUID, structures.RootUID)
+		if err == nil {
+			t.Errorf("%v no debe de existir despues del recovery", borrado)
+		}
+	}
+	issues, err := sb.CheckFilesystem(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range issues {
+		t.Errorf("[%s] %s", issue.Code, issue.Message)
+	}
+}
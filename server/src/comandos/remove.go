@@ -0,0 +1,96 @@
+package analyzer
+
+import (
+	stores "bakend/src/almacenamiento"
+	utils "bakend/src/utils"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// REMOVE estructura que representa el comando remove con sus parámetros
+type REMOVE struct {
+	path string // Path del archivo o carpeta a eliminar
+}
+
+/*
+   remove -path=/home/user/docs/a.txt
+   remove -path="/home/mis documentos"
+*/
+
+func ParseRemove(tokens []string) (*REMOVE, error) {
+	cmd := &REMOVE{} // Crea una nueva instancia de REMOVE
+
+	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
+	args := strings.Join(tokens, " ")
+	// Expresión regular para encontrar los parámetros del comando remove
+	re := regexp.MustCompile(`-(?i:path="[^"]+"|path=[^\s]+)`)
+	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
+	matches := re.FindAllString(args, -1)
+
+	// Itera sobre cada coincidencia encontrada
+	for _, match := range matches {
+		// Divide cada parte en clave y valor usando "=" como delimitador
+		kv := strings.SplitN(match, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+
+		// Remove comillas si estan present
+		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+			value = strings.Trim(value, "\"")
+		}
+
+		// Switch para manejar diferentes parámetros
+		switch key {
+		case "-path":
+			cmd.path = value
+		default:
+			// Si el parámetro no es reconocido, devuelve un error
+			return nil, fmt.Errorf("parámetro desconocido: %s", key)
+		}
+	}
+
+	// Verifica que el parámetro -path haya sido proporcionado
+	if cmd.path == "" {
+		return nil, errors.New("faltan parámetros requeridos: -path")
+	}
+
+	err := commandRemove(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd, fmt.Errorf("eliminado correctamente REMOVE: %+v", *cmd)
+}
+
+// Se elimina con los permisos del usuario logeado
+func commandRemove(remove *REMOVE) error {
+	//Obtenemos el usuario logeado
+	var usuario = ObtenerUsuari()
+
+	// Obtener la partición montada
+	partitionSuperblock, mountedPartition, partitionPath, err := stores.GetMountedPartitionSuperblock(usuario.id)
+	if err != nil {
+		return fmt.Errorf("error al obtener la partición montada: %w", err)
+	}
+
+	// GetParentDirectories obtiene las carpetas padres y el nombre a eliminar
+	parentDirs, nombre := utils.GetParentDirectories(remove.path)
+
+	err = partitionSuperblock.RemovePath(partitionPath, parentDirs, nombre, usuario.uid, usuario.gid)
+	if err != nil {
+		return fmt.Errorf("error al eliminar: %w", err)
+	}
+
+	// Serializar el superbloque con los contadores de libres actualizados
+	err = partitionSuperblock.Serialize(partitionPath, int64(mountedPartition.Part_start))
+	if err != nil {
+		return fmt.Errorf("error al serializar el superbloque: %w", err)
+	}
+
+	// Registrar la operación en el journal (solo en ext3)
+	return registrarJournal(partitionSuperblock, partitionPath, "remove", remove.path, "", usuario)
+}
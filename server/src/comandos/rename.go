@@ -0,0 +1,106 @@
+package analyzer
+
+import (
+	stores "bakend/src/almacenamiento"
+	utils "bakend/src/utils"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RENAME estructura que representa el comando rename con sus parámetros
+type RENAME struct {
+	path string // Path del archivo o carpeta
+	name string // Nuevo nombre
+}
+
+/*
+   rename -path=/home/user/docs/a.txt -name=b.txt
+   rename -path="/home/mis documentos" -name=docs
+*/
+
+func ParseRename(tokens []string) (*RENAME, error) {
+	cmd := &RENAME{} // Crea una nueva instancia de RENAME
+
+	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
+	args := strings.Join(tokens, " ")
+	// Expresión regular para encontrar los parámetros del comando rename
+	re := regexp.MustCompile(`-(?i:path="[^"]+"|path=[^\s]+|name="[^"]+"|name=[^\s]+)`)
+	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
+	matches := re.FindAllString(args, -1)
+
+	// Itera sobre cada coincidencia encontrada
+	for _, match := range matches {
+		// Divide cada parte en clave y valor usando "=" como delimitador
+		kv := strings.SplitN(match, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+
+		// Remove comillas si estan present
+		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+			value = strings.Trim(value, "\"")
+		}
+
+		// Switch para manejar diferentes parámetros
+		switch key {
+		case "-path":
+			cmd.path = value
+		case "-name":
+			// El nombre no puede contener carpetas
+			if strings.Contains(value, "/") {
+				return nil, errors.New("el name no puede contener /")
+			}
+			cmd.name = value
+		default:
+			// Si el parámetro no es reconocido, devuelve un error
+			return nil, fmt.Errorf("parámetro desconocido: %s", key)
+		}
+	}
+
+	// Verifica que los parámetros -path y -name hayan sido proporcionados
+	if cmd.path == "" {
+		return nil, errors.New("faltan parámetros requeridos: -path")
+	}
+	if cmd.name == "" {
+		return nil, errors.New("faltan parámetros requeridos: -name")
+	}
+
+	err := commandRename(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd, fmt.Errorf("renombrado correctamente RENAME: %+v", *cmd)
+}
+
+// Se renombra con los permisos del usuario logeado
+func commandRename(rename *RENAME) error {
+	//Obtenemos el usuario logeado
+	var usuario = ObtenerUsuari()
+
+	// Obtener la partición montada
+	partitionSuperblock, mountedPartition, partitionPath, err := stores.GetMountedPartitionSuperblock(usuario.id)
+	if err != nil {
+		return fmt.Errorf("error al obtener la partición montada: %w", err)
+	}
+
+	// GetParentDirectories obtiene las carpetas padres y el nombre actual
+	parentDirs, nombre := utils.GetParentDirectories(rename.path)
+
+	err = partitionSuperblock.RenamePath(partitionPath, parentDirs, nombre, rename.name, usuario.uid, usuario.gid)
+	if err != nil {
+		return fmt.Errorf("error al renombrar: %w", err)
+	}
+
+	// Serializar el superbloque por si el nuevo nombre necesito un bloque nuevo en la carpeta
+	err = partitionSuperblock.Serialize(partitionPath, int64(mountedPartition.Part_start))
+	if err != nil {
+		return fmt.Errorf("error al serializar el superbloque: %w", err)
+	}
+
+	// Registrar la operación en el journal (solo en ext3)
+	return registrarJournal(partitionSuperblock, partitionPath, "rename", rename.path, rename.name, usuario)
+}
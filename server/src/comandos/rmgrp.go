@@ -77,116 +77,52 @@ func commandRmgrp(comando *RMGRP) error {
 
 	// Obtener la partición montada
 	//Tipo de retorno: (*structures.SuperBlock, *structures.PARTITION, string, error)
-	partitionSuperblock, _, partitionPath, err := stores.GetMountedPartitionSuperblock(usuario.id)
+	partitionSuperblock, particion, partitionPath, err := stores.GetMountedPartitionSuperblock(usuario.id)
 	if err != nil {
 		return fmt.Errorf("error al obtener el Superbloque: %w", err)
 	}
 
 	//Aca iniciamos desde el inodo numero 1
-	err2 := RmgrpComand(partitionPath, usuario, comando, 1, partitionSuperblock)
+	err2 := RmgrpComand(partitionPath, usuario, comando, 1, partitionSuperblock, particion)
 
 	//validar la salida
 	if err2 != nil {
 		return fmt.Errorf("error al intenter escribir en el user.txt: %w", err2)
 	}
 
-	return nil
+	// Registrar la operación en el journal (solo en ext3)
+	return registrarJournal(partitionSuperblock, partitionPath, "rmgrp", "/users.txt", comando.name, usuario)
 }
 
 // Funcion para accder al archivo de user.txt
 // CrearUser: path del disco, objeto con los datos del usuario, el inicio de los inodos
-func RmgrpComand(path string, login *LOGIN, comando *RMGRP, inodeIndex int32, sb *structures.SuperBlock) error {
-	//Se crea una instancia de un objeto de tipo Inode
-	inode := &structures.Inode{}
-
-	// Deserializar el inodo
-	err := inode.Deserialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
+func RmgrpComand(path string, login *LOGIN, comando *RMGRP, inodeIndex int32, sb *structures.SuperBlock, mountedPartition *structures.PARTITION) error {
+	//Obtengo el texto completo del archivo users.txt
+	inode, lines, err := leerUsersTxt(path, inodeIndex, sb)
 	if err != nil {
 		return err
 	}
 
-	//Aca se almacenara el contenido de user.txt
-	data := ""
-	// Crear un nuevo bloque de archivo
-	block := &structures.FileBlock{}
-	indiceFinal := 0
-	// Iterar sobre cada bloque del inodo (apuntadores)
-	for _, blockIndex := range inode.I_block {
-		// Si el bloque no existe, salir
-		if blockIndex == -1 {
-			//Esto quiere decir que no encontro al grupo
-			return fmt.Errorf("no se encontro ningun grupo con el name: %s", comando.name)
-		}
-		indiceFinal = int(blockIndex)
-		// Crear un nuevo bloque de archivo
-		block = &structures.FileBlock{}
-
-		// Deserializar el bloque desde el incio de los blokes  + posicion por el peso de los bloques  que es 64
-		err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size))) // 64 porque es el tamaño de un bloque
-		if err != nil {
-			return err
-		}
+	encontrado := false
+	for i, line := range lines {
+		values := strings.Split(line, ",")
 
-		//Obtengo el texto del archivo uset.txt, de un fileblock
-		data = strings.Trim(string(block.B_content[:]), "\x00 ")
-
-		/*
-			1,G,root
-			1,U,root,root,123
-		*/
-		// Dividir por salto de línea
-		lines := strings.Split(data, "\n")
-		// Recorrer cada línea y dividir por comas
-		var id = ""
-		var tipo = ""
-		var nombre = ""
-
-		//Esta variable almacenara el resultado
-		result := ""
-		encontrado := false
-
-		//Esto es para obtner los datos puntuales
-		for _, line := range lines {
-			values := strings.Split(line, ",")
-
-			// Almacenar en variables según la cantidad de datos
-			//Esto son los grupos
-			if len(values) == 3 {
-				id, tipo, nombre = values[0], values[1], values[2]
-				if nombre == comando.name {
-					//Esto quiere decir que ya esta borrado
-					if id == "0" {
-						return fmt.Errorf("el grupo con el name: %s ya fue borrado", comando.name)
-					}
-					encontrado = true
-					//Se edita el id del grupo
-					result += "0," + tipo + "," + nombre //+ "\n"
-					//Continua por si hay mas datos y se agregan al contenido del fileblock
-					continue
-				}
-				//fmt.Printf("ID: %s, Tipo: %s, Nombre: %s\n", id, tipo, nombre)
+		//Esto son los grupos
+		if len(values) == 3 && values[2] == comando.name {
+			//Esto quiere decir que ya esta borrado
+			if values[0] == "0" {
+				return fmt.Errorf("el grupo con el name: %s ya fue borrado", comando.name)
 			}
-			result += line + "\n"
-		}
-
-		if encontrado {
-
-			// Copiamos el texto de usuarios en el bloque
-			copy(block.B_content[:], []byte(result))
-			//Se serealiza todo el contenido en el Fileblock
-			err2 := block.Serialize(path, int64(sb.S_block_start+(int32(indiceFinal)*sb.S_block_size)))
-			if err2 != nil {
-				return err2
-			}
-			// fmt.Println("**********")
-			// block.Print()
-			//Finalizamos el bucle
+			encontrado = true
+			//Se edita el id del grupo
+			lines[i] = "0," + values[1] + "," + values[2]
 			break
 		}
-
 	}
 
-	//block.Print()
+	if !encontrado {
+		return fmt.Errorf("no se encontro ningun grupo con el name: %s", comando.name)
+	}
 
-	return nil
+	return escribirUsersTxt(path, inodeIndex, inode, lines, sb, mountedPartition)
 }
@@ -0,0 +1,158 @@
+package analyzer
+
+import (
+	stores "bakend/src/almacenamiento"
+	structures "bakend/src/estructuras"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// UNMOUNT estructura que representa el comando unmount con sus parámetros
+type UNMOUNT struct {
+	id string // ID de la partición montada
+}
+
+/*
+	unmount -id=271A
+*/
+
+// ParseUnmount parsea el comando unmount y devuelve una instancia de UNMOUNT
+func ParseUnmount(tokens []string) (*UNMOUNT, error) {
+	cmd := &UNMOUNT{} // Crea una nueva instancia de UNMOUNT
+
+	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
+	args := strings.Join(tokens, " ")
+	// Expresión regular para encontrar los parámetros del comando unmount
+	re := regexp.MustCompile(`-(?i:id=[^\s]+)`)
+	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
+	matches := re.FindAllString(args, -1)
+
+	// Itera sobre cada coincidencia encontrada
+	for _, match := range matches {
+		// Divide cada parte en clave y valor usando "=" como delimitador
+		kv := strings.SplitN(match, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("formato de parámetro inválido: %s", match)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+
+		// Switch para manejar diferentes parámetros
+		switch key {
+		case "-id":
+			// Verifica que el id no esté vacío
+			if value == "" {
+				return nil, errors.New("el id no puede estar vacío")
+			}
+			cmd.id = value
+		default:
+			// Si el parámetro no es reconocido, devuelve un error
+			return nil, fmt.Errorf("parámetro desconocido: %s", key)
+		}
+	}
+
+	// Verifica que el parámetro -id haya sido proporcionado
+	if cmd.id == "" {
+		return nil, errors.New("faltan parámetros requeridos: -id")
+	}
+
+	// Desmontamos la partición
+	err := commandUnmount(cmd)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return nil, err
+	}
+
+	return cmd, fmt.Errorf("unmount realizado: %+v", *cmd) // Devuelve el comando UNMOUNT creado
+}
+
+func commandUnmount(unmount *UNMOUNT) error {
+	// Obtener la partición montada, tambien resuelve las particiones lógicas
+	partition, path, err := stores.GetMountedPartition(unmount.id)
+	if err != nil {
+		return fmt.Errorf("error en el unmount: la partición %s no está montada", unmount.id)
+	}
+
+	// Si la partición tiene un sistema de archivos se actualiza la fecha de desmontaje
+	var sb structures.SuperBlock
+	err = sb.Deserialize(path, int64(partition.Part_start))
+	if err == nil && sb.S_magic == 0xEF53 {
+		sb.S_umtime = time.Now().Unix()
+		err = sb.Serialize(path, int64(partition.Part_start))
+		if err != nil {
+			return fmt.Errorf("error al actualizar el superbloque: %w", err)
+		}
+	}
+
+	// Modificamos la partición (o su EBR) para indicar que ya no está montada
+	if partition.Part_type[0] == 'L' {
+		err = unmountLogicPartition(path, partition)
+	} else {
+		err = unmountPrimaryPartition(path, unmount.id)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Quitar la partición de la lista de montajes globales
+	stores.RemoveMountedPartition(unmount.id)
+	err = stores.SaveMountState()
+	if err != nil {
+		return fmt.Errorf("error guardando el estado de los montajes: %w", err)
+	}
+
+	// Si el usuario logeado estaba usando la partición se cierra su sesión
+	LogoutParticion(unmount.id)
+
+	return nil
+}
+
+// Limpia el estado de montaje de la partición en el MBR
+func unmountPrimaryPartition(path string, id string) error {
+	// Crear una instancia de MBR
+	var mbr structures.MBR
+
+	// Deserializar la estructura MBR desde un archivo binario
+	err := mbr.DeserializeMBR(path)
+	if err != nil {
+		fmt.Println("Error deserializando el MBR:", err)
+		return err
+	}
+
+	// Buscar la partición con el id especificado
+	partition, err := mbr.GetPartitionByID(id)
+	if partition == nil {
+		return fmt.Errorf("error en el unmount: %w", err)
+	}
+	partition.UnmountPartition()
+
+	// Serializar la estructura MBR en el archivo binario
+	err = mbr.SerializeMBR(path)
+	if err != nil {
+		fmt.Println("Error serializando el MBR:", err)
+		return err
+	}
+	return nil
+}
+
+// Limpia el estado de montaje de la partición lógica en su EBR
+func unmountLogicPartition(path string, partition *structures.PARTITION) error {
+	// El EBR esta justo antes del inicio de la partición lógica
+	var ebr structures.EBR
+	position := partition.Part_start - int32(binary.Size(ebr))
+	err := ebr.DeserializeEBR(path, position)
+	if err != nil {
+		return err
+	}
+
+	ebr.Ebr_mount[0] = 'N'
+	err = ebr.SerializeEBR(path, position)
+	if err != nil {
+		fmt.Println("Error serializando el EBR:", err)
+		return err
+	}
+	return nil
+}
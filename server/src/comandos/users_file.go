@@ -0,0 +1,84 @@
+package analyzer
+
+import (
+	structures "bakend/src/estructuras"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Lee el contenido completo de users.txt (bloques directos e indirectos) separado por lineas
+func leerUsersTxt(path string, inodeIndex int32, sb *structures.SuperBlock) (*structures.Inode, []string, error) {
+	inode := &structures.Inode{}
+
+	// Deserializar el inodo
+	err := inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := sb.ReadFileContent(path, inode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	/*
+		1,G,root
+		1,U,root,root,123
+	*/
+	// Dividir por salto de línea, sin la linea vacia del final
+	data = strings.Trim(data, "\x00 \n")
+	if data == "" {
+		return inode, nil, nil
+	}
+	return inode, strings.Split(data, "\n"), nil
+}
+
+// Reescribe users.txt con las lineas indicadas y guarda el superbloque por los bloques reservados
+func escribirUsersTxt(path string, inodeIndex int32, inode *structures.Inode, lines []string, sb *structures.SuperBlock, mountedPartition *structures.PARTITION) error {
+	data := strings.Join(lines, "\n") + "\n"
+
+	err := sb.WriteFileContent(path, inodeIndex, inode, data)
+	if err != nil {
+		return err
+	}
+
+	// Serializar el superbloque
+	err = sb.Serialize(path, int64(mountedPartition.Part_start))
+	if err != nil {
+		return fmt.Errorf("error al serializar el superbloque: %w", err)
+	}
+	return nil
+}
+
+// Busca el usuario activo en las lineas de users.txt y retorna su id y el id de su grupo
+func buscarUsuario(lines []string, nombre string) (int32, int32, error) {
+	grupo := ""
+	uid := -1
+	for _, line := range lines {
+		values := strings.Split(line, ",")
+		if len(values) == 5 && values[0] != "0" && values[3] == nombre {
+			id, err := strconv.Atoi(values[0])
+			if err != nil {
+				return -1, -1, fmt.Errorf("error el id del usuario %s no es valido: %s", nombre, values[0])
+			}
+			uid = id
+			grupo = values[2]
+		}
+	}
+	if uid == -1 {
+		return -1, -1, fmt.Errorf("error el usuario %s no existe", nombre)
+	}
+
+	for _, line := range lines {
+		values := strings.Split(line, ",")
+		if len(values) == 3 && values[0] != "0" && values[2] == grupo {
+			gid, err := strconv.Atoi(values[0])
+			if err != nil {
+				return -1, -1, fmt.Errorf("error el id del grupo %s no es valido: %s", grupo, values[0])
+			}
+			return int32(uid), int32(gid), nil
+		}
+	}
+	return -1, -1, fmt.Errorf("error el grupo %s del usuario %s no existe", grupo, nombre)
+}
@@ -0,0 +1,42 @@
+package analyzer
+
+import "testing"
+
+func TestBuscarUsuario(t *testing.T) {
+	lines := []string{
+		"1,G,root",
+		"1,U,root,root,123",
+		"2,G,usuarios",
+		"0,G,borrado",
+		"2,U,usuarios,ana,abc",
+		"3,U,borrado,luis,abc",
+		"0,U,usuarios,eliminado,abc",
+	}
+	pruebas := []struct {
+		nombre   string
+		uid, gid int32
+		falla    bool
+	}{
+		{nombre: "root", uid: 1, gid: 1},
+		{nombre: "ana", uid: 2, gid: 2},
+		{nombre: "luis", falla: true},      // Su grupo fue eliminado
+		{nombre: "eliminado", falla: true}, // El usuario fue eliminado
+		{nombre: "nadie", falla: true},
+	}
+	for _, prueba := range pruebas {
+		uid, gid, err := buscarUsuario(lines, prueba.nombre)
+		if prueba.falla {
+			if err == nil {
+				t.Errorf("%s: se esperaba un error, se obtuvo uid %d gid %d", prueba.nombre, uid, gid)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", prueba.nombre, err)
+			continue
+		}
+		if uid != prueba.uid || gid != prueba.gid {
+			t.Errorf("%s: uid %d gid %d, se esperaba %d y %d", prueba.nombre, uid, gid, prueba.uid, prueba.gid)
+		}
+	}
+}
@@ -2,6 +2,9 @@ package structures
 
 import (
 	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 )
 
@@ -55,50 +58,211 @@ func (sb *SuperBlock) CreateBitMaps(path string) error {
 	return nil
 }
 
-// Actualizar Bitmap de inodos
-func (sb *SuperBlock) UpdateBitmapInode(path string) error {
-	// Abrir el archivo
+// Total de inodos del sistema de archivos (usados + libres)
+func (sb *SuperBlock) TotalInodes() int32 {
+	return sb.S_inodes_count + sb.S_free_inodes_count
+}
+
+// Total de bloques del sistema de archivos (usados + libres)
+func (sb *SuperBlock) TotalBlocks() int32 {
+	return sb.S_blocks_count + sb.S_free_blocks_count
+}
+
+// Lee el bitmap completo que inicia en start
+func readBitmap(path string, start int32, total int32) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	_, err = file.Seek(int64(start), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	bitmap := make([]byte, total)
+	_, err = io.ReadFull(file, bitmap)
+	if err != nil {
+		return nil, err
+	}
+	return bitmap, nil
+}
+
+// Escribe un valor ('0' o '1') en la posición index del bitmap que inicia en start
+func writeBitmap(path string, start int32, index int32, value byte) error {
 	file, err := os.OpenFile(path, os.O_RDWR, 0644)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	// Mover el puntero del archivo a la posición del bitmap de inodos
-	_, err = file.Seek(int64(sb.S_bm_inode_start)+int64(sb.S_inodes_count), 0)
+	_, err = file.Seek(int64(start)+int64(index), 0)
 	if err != nil {
 		return err
 	}
 
-	// Escribir el bit en el archivo
-	_, err = file.Write([]byte{'1'})
+	_, err = file.Write([]byte{value})
+	return err
+}
+
+// Retorna el primer índice libre del bitmap a partir de desde, o -1 si esta lleno
+func firstFree(bitmap []byte, desde int32) int32 {
+	for i := desde; i < int32(len(bitmap)); i++ {
+		if bitmap[i] != '1' {
+			return i
+		}
+	}
+	return -1
+}
+
+// Retorna los índices marcados como usados en el bitmap
+func usedIndexes(bitmap []byte) []int32 {
+	var usados []int32
+	for i, b := range bitmap {
+		if b == '1' {
+			usados = append(usados, int32(i))
+		}
+	}
+	return usados
+}
+
+// Obtiene los índices de todos los inodos usados según el bitmap de inodos
+func (sb *SuperBlock) GetUsedInodes(path string) ([]int32, error) {
+	bitmap, err := readBitmap(path, sb.S_bm_inode_start, sb.TotalInodes())
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return usedIndexes(bitmap), nil
+}
 
-	return nil
+// Obtiene los índices de todos los bloques usados según el bitmap de bloques
+func (sb *SuperBlock) GetUsedBlocks(path string) ([]int32, error) {
+	bitmap, err := readBitmap(path, sb.S_bm_block_start, sb.TotalBlocks())
+	if err != nil {
+		return nil, err
+	}
+	return usedIndexes(bitmap), nil
 }
 
-// Actualizar Bitmap de bloques
-func (sb *SuperBlock) UpdateBitmapBlock(path string) error {
-	// Abrir el archivo
-	file, err := os.OpenFile(path, os.O_RDWR, 0644)
+// AllocateInode busca el primer inodo libre en el bitmap, lo marca como usado y retorna su índice
+// S_first_ino queda apuntando al siguiente inodo libre
+func (sb *SuperBlock) AllocateInode(path string) (int32, error) {
+	bitmap, err := readBitmap(path, sb.S_bm_inode_start, sb.TotalInodes())
+	if err != nil {
+		return -1, err
+	}
+
+	index := firstFree(bitmap, 0)
+	if index == -1 {
+		return -1, errors.New("no hay inodos libres en el sistema de archivos")
+	}
+
+	err = writeBitmap(path, sb.S_bm_inode_start, index, '1')
+	if err != nil {
+		return -1, err
+	}
+
+	// Actualizar el superbloque
+	sb.S_inodes_count++
+	sb.S_free_inodes_count--
+	siguiente := firstFree(bitmap, index+1)
+	if siguiente == -1 {
+		siguiente = sb.TotalInodes()
+	}
+	sb.S_first_ino = sb.S_inode_start + siguiente*sb.S_inode_size
+
+	return index, nil
+}
+
+// AllocateBlock busca el primer bloque libre en el bitmap, lo marca como usado y retorna su índice
+// S_first_blo queda apuntando al siguiente bloque libre
+func (sb *SuperBlock) AllocateBlock(path string) (int32, error) {
+	bitmap, err := readBitmap(path, sb.S_bm_block_start, sb.TotalBlocks())
+	if err != nil {
+		return -1, err
+	}
+
+	index := firstFree(bitmap, 0)
+	if index == -1 {
+		return -1, errors.New("no hay bloques libres en el sistema de archivos")
+	}
+
+	err = writeBitmap(path, sb.S_bm_block_start, index, '1')
+	if err != nil {
+		return -1, err
+	}
+
+	// Actualizar el superbloque
+	sb.S_blocks_count++
+	sb.S_free_blocks_count--
+	siguiente := firstFree(bitmap, index+1)
+	if siguiente == -1 {
+		siguiente = sb.TotalBlocks()
+	}
+	sb.S_first_blo = sb.S_block_start + siguiente*sb.S_block_size
+
+	return index, nil
+}
+
+// FreeInode marca el inodo como libre en el bitmap para que se pueda reutilizar
+func (sb *SuperBlock) FreeInode(path string, index int32) error {
+	if index < 0 || index >= sb.TotalInodes() {
+		return fmt.Errorf("inodo fuera de rango: %d", index)
+	}
+
+	bitmap, err := readBitmap(path, sb.S_bm_inode_start, sb.TotalInodes())
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	// Si ya estaba libre no se modifica el superbloque
+	if bitmap[index] != '1' {
+		return nil
+	}
 
-	// Mover el puntero del archivo a la posición del bitmap de bloques
-	_, err = file.Seek(int64(sb.S_bm_block_start)+int64(sb.S_blocks_count), 0)
+	err = writeBitmap(path, sb.S_bm_inode_start, index, '0')
 	if err != nil {
 		return err
 	}
 
-	// Escribir el bit en el archivo
-	_, err = file.Write([]byte{'1'})
+	// Actualizar el superbloque
+	sb.S_inodes_count--
+	sb.S_free_inodes_count++
+	posicion := sb.S_inode_start + index*sb.S_inode_size
+	if posicion < sb.S_first_ino {
+		sb.S_first_ino = posicion
+	}
+
+	return nil
+}
+
+// FreeBlock marca el bloque como libre en el bitmap para que se pueda reutilizar
+func (sb *SuperBlock) FreeBlock(path string, index int32) error {
+	if index < 0 || index >= sb.TotalBlocks() {
+		return fmt.Errorf("bloque fuera de rango: %d", index)
+	}
+
+	bitmap, err := readBitmap(path, sb.S_bm_block_start, sb.TotalBlocks())
+	if err != nil {
+		return err
+	}
+	// Si ya estaba libre no se modifica el superbloque
+	if bitmap[index] != '1' {
+		return nil
+	}
+
+	err = writeBitmap(path, sb.S_bm_block_start, index, '0')
 	if err != nil {
 		return err
 	}
 
+	// Actualizar el superbloque
+	sb.S_blocks_count--
+	sb.S_free_blocks_count++
+	posicion := sb.S_block_start + index*sb.S_block_size
+	if posicion < sb.S_first_blo {
+		sb.S_first_blo = posicion
+	}
+
 	return nil
 }
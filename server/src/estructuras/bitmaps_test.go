@@ -0,0 +1,163 @@
+package structures
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Crea un disco temporal con un sistema de archivos ext2 formateado desde el byte 0
+// Tiene n inodos y 3 bloques por inodo de blockSize bytes, con / y users.txt
+func newTestFilesystem(t *testing.T, n int32, blockSize int32) (*SuperBlock, string) {
+	t.Helper()
+
+	sb, path := newTestSuperBlock(t, n, blockSize)
+	err := sb.CreateUsersFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sb, path
+}
+
+// Igual que newTestFilesystem pero solo con los bitmaps, sin / ni users.txt
+func newTestSuperBlock(t *testing.T, n int32, blockSize int32) (*SuperBlock, string) {
+	t.Helper()
+
+	ratio := int32(3)
+	bmInodeStart := int32(binary.Size(SuperBlock{}))
+	bmBlockStart := bmInodeStart + n
+	inodeStart := bmBlockStart + ratio*n
+	blockStart := inodeStart + int32(binary.Size(Inode{}))*n
+
+	path := filepath.Join(t.TempDir(), "disco.mia")
+	err := os.WriteFile(path, make([]byte, blockStart+ratio*n*blockSize), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sb := &SuperBlock{
+		S_filesystem_type:   2,
+		S_free_inodes_count: n,
+		S_free_blocks_count: ratio * n,
+		S_mnt_count:         1,
+		S_magic:             0xEF53,
+		S_inode_size:        int32(binary.Size(Inode{})),
+		S_block_size:        blockSize,
+		S_first_ino:         inodeStart,
+		S_first_blo:         blockStart,
+		S_bm_inode_start:    bmInodeStart,
+		S_bm_block_start:    bmBlockStart,
+		S_inode_start:       inodeStart,
+		S_block_start:       blockStart,
+		S_version:           FormatVersion,
+	}
+	err = sb.CreateBitMaps(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sb, path
+}
+
+func TestAllocateReusesFreedIndexes(t *testing.T) {
+	sb, path := newTestFilesystem(t, 16, 64)
+
+	// / y users.txt usan los inodos 0 y 1 y los bloques 0 y 1
+	inodo, err := sb.AllocateInode(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bloque, err := sb.AllocateBlock(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inodo != 2 || bloque != 2 {
+		t.Fatalf("se esperaba el inodo 2 y el bloque 2, se obtuvo %d y %d", inodo, bloque)
+	}
+
+	siguiente, err := sb.AllocateInode(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.FreeInode(path, inodo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.FreeBlock(path, bloque)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sb.S_first_ino != sb.S_inode_start+inodo*sb.S_inode_size || sb.S_first_blo != sb.S_block_start+bloque*sb.S_block_size {
+		t.Errorf("S_first_ino y S_first_blo deben apuntar a los espacios liberados")
+	}
+
+	// Los espacios liberados se reutilizan antes que los siguientes
+	reutilizado, err := sb.AllocateInode(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reutilizado != inodo {
+		t.Errorf("se esperaba reutilizar el inodo %d, se obtuvo %d", inodo, reutilizado)
+	}
+	if siguiente == reutilizado {
+		t.Errorf("el inodo %d se asigno dos veces", siguiente)
+	}
+	reutilizado, err = sb.AllocateBlock(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reutilizado != bloque {
+		t.Errorf("se esperaba reutilizar el bloque %d, se obtuvo %d", bloque, reutilizado)
+	}
+}
+
+func TestFreeUpdatesCountersOnce(t *testing.T) {
+	sb, path := newTestFilesystem(t, 16, 64)
+
+	bloque, err := sb.AllocateBlock(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usados, libres := sb.S_blocks_count, sb.S_free_blocks_count
+
+	// Liberar dos veces el mismo bloque solo cambia los contadores una vez
+	for i := 0; i < 2; i++ {
+		err = sb.FreeBlock(path, bloque)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if sb.S_blocks_count != usados-1 || sb.S_free_blocks_count != libres+1 {
+		t.Errorf("contadores %d/%d, se esperaba %d/%d", sb.S_blocks_count, sb.S_free_blocks_count, usados-1, libres+1)
+	}
+
+	err = sb.FreeInode(path, sb.TotalInodes())
+	if err == nil {
+		t.Error("se esperaba un error al liberar un inodo fuera de rango")
+	}
+}
+
+func TestAllocateFailsWhenFull(t *testing.T) {
+	sb, path := newTestFilesystem(t, 4, 64)
+
+	for sb.S_free_blocks_count > 0 {
+		_, err := sb.AllocateBlock(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err := sb.AllocateBlock(path)
+	if err == nil {
+		t.Fatal("se esperaba un error sin bloques libres")
+	}
+
+	// La carpeta no se crea y su inodo queda libre
+	inodosLibres := sb.S_free_inodes_count
+	err = sb.CreateFolder(false, path, nil, "docs", RootUID, RootUID)
+	if err == nil {
+		t.Fatal("se esperaba un error al crear la carpeta sin bloques libres")
+	}
+	if sb.S_free_inodes_count != inodosLibres {
+		t.Errorf("inodos libres %d, se esperaba %d", sb.S_free_inodes_count, inodosLibres)
+	}
+}
@@ -0,0 +1,118 @@
+package structures
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxNameLength retorna el largo máximo de un nombre, todas sus partes deben de caber en un bloque de carpeta
+// Un nombre que no cabe en los bloques actuales usa un bloque nuevo, que no tiene . y ..
+func (sb *SuperBlock) MaxNameLength() int {
+	entradas := int(sb.S_block_size) / binary.Size(FolderContent{})
+	return entradas * len(FolderContent{}.B_name)
+}
+
+// ValidateName retorna un error si el nombre no se puede guardar en una carpeta, los nombres nunca se truncan
+func (sb *SuperBlock) ValidateName(name string) error {
+	if name == "." || name == ".." {
+		return fmt.Errorf("error el nombre %s esta reservado", name)
+	}
+	if len(name) > sb.MaxNameLength() {
+		return fmt.Errorf("error el nombre %s es demasiado largo, maximo %d caracteres", name, sb.MaxNameLength())
+	}
+	return nil
+}
+
+// GetFolderEntries retorna las entradas de la carpeta inodeIndex sin contar . y ..
+// Se recorren todos los bloques de la carpeta, directos e indirectos
+func (sb *SuperBlock) GetFolderEntries(path string, inodeIndex int32) ([]FolderEntry, error) {
+	inode := &Inode{}
+	err := inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		return nil, err
+	}
+	if inode.I_type[0] != '0' {
+		return nil, errors.New("error los directorios de la ruta es un archivo")
+	}
+
+	bloques, err := sb.GetInodeBlocks(path, inode)
+	if err != nil {
+		return nil, err
+	}
+
+	var entradas []FolderEntry
+	for _, blockIndex := range bloques {
+		block := NewFolderBlock(sb.S_block_size)
+		err := block.Deserialize(path, sb.BlockOffset(blockIndex))
+		if err != nil {
+			return nil, err
+		}
+
+		// Las entradas libres pueden quedar en medio de la carpeta
+		entradas = append(entradas, block.Entries()...)
+	}
+	return entradas, nil
+}
+
+// ResolvePath recorre la ruta desde la carpeta raíz y retorna el índice del inodo al que apunta
+// Los componentes vacíos se ignoran, por lo que una ruta vacía es la raíz
+// El usuario debe de tener permiso de ejecución sobre cada carpeta que se recorre
+func (sb *SuperBlock) ResolvePath(path string, components []string, uid int32, gid int32) (int32, error) {
+	posicion := int32(0)
+	for _, component := range components {
+		if component == "" {
+			continue
+		}
+		siguiente, err := sb.Encontrar_Directorio(path, posicion, component, uid, gid)
+		if err != nil {
+			return -1, err
+		}
+		if siguiente == -1 {
+			return -1, fmt.Errorf("error la ruta %s no existe", component)
+		}
+		posicion = siguiente
+	}
+	return posicion, nil
+}
+
+// Quita de la carpeta parentIndex la entrada name que apunta a childIndex, el espacio queda libre para reutilizarlo
+// Se compara el nombre porque varios enlaces duros de la misma carpeta apuntan al mismo inodo
+// Si el nombre es largo tambien se liberan sus entradas de continuación
+func (sb *SuperBlock) removeFolderEntry(path string, parentIndex int32, name string, childIndex int32) error {
+	inode := &Inode{}
+	err := inode.Deserialize(path, sb.InodeOffset(parentIndex))
+	if err != nil {
+		return err
+	}
+
+	bloques, err := sb.GetInodeBlocks(path, inode)
+	if err != nil {
+		return err
+	}
+
+	for _, blockIndex := range bloques {
+		block := NewFolderBlock(sb.S_block_size)
+		err := block.Deserialize(path, sb.BlockOffset(blockIndex))
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range block.Entries() {
+			if entry.B_inodo != childIndex || !strings.EqualFold(entry.GetName(), name) {
+				continue
+			}
+			block.clearEntry(entry)
+			err := block.Serialize(path, sb.BlockOffset(blockIndex))
+			if err != nil {
+				return err
+			}
+
+			inode.touchModify()
+			return inode.Serialize(path, sb.InodeOffset(parentIndex))
+		}
+	}
+
+	return errors.New("error la entrada no existe en la carpeta padre")
+}
@@ -0,0 +1,231 @@
+package structures
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Códigos de los problemas que puede encontrar checkdisk
+const (
+	IssueDiskSize         = "TAMANO_DISCO"           // Mbr_size no coincide con el tamaño real del archivo
+	IssueOutOfRange       = "FUERA_DE_RANGO"         // Partición fuera de los limites del disco
+	IssueOverlap          = "TRASLAPE"               // Dos particiones ocupan el mismo espacio
+	IssueMultipleExtended = "VARIAS_EXTENDIDAS"      // Hay más de una partición extendida
+	IssueBrokenChain      = "CADENA_ROTA"            // Ebr_next apunta fuera de la extendida o hacia atras (ciclo)
+	IssueDuplicateName    = "NOMBRE_DUPLICADO"       // Dos particiones con el mismo nombre
+	IssueLogicalOutside   = "LOGICA_FUERA_EXTENDIDA" // Partición lógica fuera de su extendida
+)
+
+// DiskIssue representa un problema encontrado al validar un disco
+type DiskIssue struct {
+	Code       string // Código del problema
+	Message    string // Descripción del problema
+	Repairable bool   // Indica si el problema se puede reparar de forma segura
+	Repaired   bool   // Indica si el problema ya fue reparado
+	repair     func() error
+}
+
+// String da el formato con el que se muestra el problema en la consola
+func (issue DiskIssue) String() string {
+	estado := ""
+	if issue.Repaired {
+		estado = " (reparado)"
+	} else if issue.Repairable {
+		estado = " (reparable con -repair)"
+	}
+	return fmt.Sprintf("[%s] %s%s", issue.Code, issue.Message, estado)
+}
+
+// Espacio ocupado por una partición, se usa para revisar traslapes y nombres
+type checkedPartition struct {
+	name  string
+	start int32
+	end   int32
+}
+
+// CheckDisk valida el MBR y la cadena de EBRs del disco y retorna los problemas encontrados
+// Si repair es true se aplican las reparaciones seguras
+func CheckDisk(path string, repair bool) ([]DiskIssue, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var mbr MBR
+	err = mbr.DeserializeMBR(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []DiskIssue
+	mbrSize := int32(binary.Size(mbr))
+
+	// El archivo debe de tener al menos el tamaño que indica el MBR
+	if info.Size() < int64(mbr.Mbr_size) {
+		issues = append(issues, DiskIssue{
+			Code:       IssueDiskSize,
+			Message:    fmt.Sprintf("el MBR indica %d bytes pero el archivo tiene %d", mbr.Mbr_size, info.Size()),
+			Repairable: true,
+			repair: func() error {
+				// Se completa el archivo con ceros hasta el tamaño del MBR
+				return os.Truncate(path, int64(mbr.Mbr_size))
+			},
+		})
+	} else if info.Size() > int64(mbr.Mbr_size) {
+		issues = append(issues, DiskIssue{
+			Code:    IssueDiskSize,
+			Message: fmt.Sprintf("el archivo tiene %d bytes pero el MBR indica %d", info.Size(), mbr.Mbr_size),
+		})
+	}
+
+	// Revisar las particiones del MBR
+	var primarias []checkedPartition
+	var extendidas []PARTITION
+	for _, partition := range mbr.Mbr_partitions {
+		if partition.Part_start == -1 {
+			continue
+		}
+		name := partition.GetName()
+		if partition.Part_size <= 0 || partition.Part_start < mbrSize || partition.Part_start+partition.Part_size > mbr.Mbr_size {
+			issues = append(issues, DiskIssue{
+				Code:    IssueOutOfRange,
+				Message: fmt.Sprintf("la partición %s (inicio %d, tamaño %d) esta fuera del disco", name, partition.Part_start, partition.Part_size),
+			})
+		}
+		if partition.Part_type[0] == 'E' {
+			extendidas = append(extendidas, partition)
+		}
+		primarias = append(primarias, checkedPartition{name: name, start: partition.Part_start, end: partition.Part_start + partition.Part_size})
+	}
+	issues = append(issues, checkOverlaps(primarias, "")...)
+
+	if len(extendidas) > 1 {
+		issues = append(issues, DiskIssue{
+			Code:    IssueMultipleExtended,
+			Message: fmt.Sprintf("el disco tiene %d particiones extendidas", len(extendidas)),
+		})
+	}
+
+	// Revisar la cadena de EBRs de cada extendida
+	nombres := append([]checkedPartition{}, primarias...)
+	for _, extendida := range extendidas {
+		logicas, chainIssues, err := checkEBRChain(path, extendida, info.Size())
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, chainIssues...)
+		issues = append(issues, checkOverlaps(logicas, extendida.GetName())...)
+		nombres = append(nombres, logicas...)
+	}
+
+	// Los nombres deben de ser únicos en todo el disco
+	vistos := make(map[string]bool)
+	for _, partition := range nombres {
+		key := strings.ToLower(partition.name)
+		if vistos[key] {
+			issues = append(issues, DiskIssue{
+				Code:    IssueDuplicateName,
+				Message: fmt.Sprintf("el nombre %s esta repetido", partition.name),
+			})
+		}
+		vistos[key] = true
+	}
+
+	if repair {
+		for i := range issues {
+			if !issues[i].Repairable {
+				continue
+			}
+			err := issues[i].repair()
+			if err != nil {
+				return issues, fmt.Errorf("error reparando %s: %w", issues[i].Code, err)
+			}
+			issues[i].Repaired = true
+		}
+	}
+
+	return issues, nil
+}
+
+// Recorre la cadena de EBRs de la extendida y retorna las particiones lógicas encontradas
+func checkEBRChain(path string, extendida PARTITION, fileSize int64) ([]checkedPartition, []DiskIssue, error) {
+	var logicas []checkedPartition
+	var issues []DiskIssue
+
+	ebrSize := int32(binary.Size(EBR{}))
+	inicio := extendida.Part_start
+	fin := extendida.Part_start + extendida.Part_size
+
+	// Si la extendida no esta dentro del archivo no se puede leer la cadena
+	if int64(inicio)+int64(ebrSize) > fileSize {
+		return nil, nil, nil
+	}
+
+	position := inicio
+	for position != -1 {
+		var ebr EBR
+		err := ebr.DeserializeEBR(path, position)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !ebr.IsEmpty() {
+			name := ebr.GetName()
+			dataEnd := ebr.Ebr_start + ebrSize + ebr.Ebr_size
+			if ebr.Ebr_start < inicio || dataEnd > fin {
+				issues = append(issues, DiskIssue{
+					Code:    IssueLogicalOutside,
+					Message: fmt.Sprintf("la partición lógica %s (inicio %d, tamaño %d) no esta dentro de la extendida %s", name, ebr.Ebr_start, ebr.Ebr_size, extendida.GetName()),
+				})
+			}
+			logicas = append(logicas, checkedPartition{name: name, start: ebr.Ebr_start, end: dataEnd})
+		}
+
+		// El siguiente EBR debe de estar despues del actual y dentro de la extendida
+		next := ebr.Ebr_next
+		if next == -1 {
+			break
+		}
+		if next <= position || next+ebrSize > fin || int64(next)+int64(ebrSize) > fileSize {
+			ultimo := ebr
+			ultimaPosicion := position
+			issues = append(issues, DiskIssue{
+				Code:       IssueBrokenChain,
+				Message:    fmt.Sprintf("el EBR en el byte %d apunta a %d, fuera de la extendida %s o hacia atras", position, next, extendida.GetName()),
+				Repairable: true,
+				repair: func() error {
+					// Se corta la cadena en el último EBR válido
+					ultimo.Ebr_next = -1
+					return ultimo.SerializeEBR(path, ultimaPosicion)
+				},
+			})
+			break
+		}
+		position = next
+	}
+
+	return logicas, issues, nil
+}
+
+// Revisa que ningun par de particiones ocupe el mismo espacio
+func checkOverlaps(particiones []checkedPartition, extendida string) []DiskIssue {
+	var issues []DiskIssue
+	for i := 0; i < len(particiones); i++ {
+		for j := i + 1; j < len(particiones); j++ {
+			a, b := particiones[i], particiones[j]
+			if a.start < b.end && b.start < a.end {
+				lugar := "el disco"
+				if extendida != "" {
+					lugar = "la extendida " + extendida
+				}
+				issues = append(issues, DiskIssue{
+					Code:    IssueOverlap,
+					Message: fmt.Sprintf("las particiones %s y %s se traslapan en %s", a.name, b.name, lugar),
+				})
+			}
+		}
+	}
+	return issues
+}
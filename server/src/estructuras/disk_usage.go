@@ -0,0 +1,76 @@
+package structures
+
+import "strings"
+
+// DiskUsage es la cantidad de bloques que usa una carpeta o archivo con todo su contenido
+type DiskUsage struct {
+	Ruta    string
+	Bloques int32 // Bloques de carpetas, archivos y apuntadores
+}
+
+// DiskUsagePath suma los bloques que usa la ruta inicio, incluyendo los bloques de apuntadores
+// Retorna el uso de cada carpeta en postorden, la última es la ruta inicio, con resumen solo retorna la ruta inicio
+// Un inodo con varios enlaces duros se cuenta una sola vez y las carpetas sin permiso de lectura o de ejecución se omiten
+func (sb *SuperBlock) DiskUsagePath(path string, inicio []string, resumen bool, uid int32, gid int32) ([]DiskUsage, error) {
+	inodeIndex, err := sb.ResolvePath(path, inicio, uid, gid)
+	if err != nil {
+		return nil, err
+	}
+
+	ruta := "/" + strings.Join(inicio, "/")
+	if strings.Join(inicio, "") == "" {
+		ruta = "/"
+	}
+
+	var usos []DiskUsage
+	vistos := make(map[int32]bool)
+	total, err := sb.diskUsage(path, inodeIndex, ruta, vistos, &usos, uid, gid)
+	if err != nil {
+		return nil, err
+	}
+
+	if resumen || len(usos) == 0 || usos[len(usos)-1].Ruta != ruta {
+		return []DiskUsage{{Ruta: ruta, Bloques: total}}, nil
+	}
+	return usos, nil
+}
+
+// Retorna los bloques del inodo y de su contenido, agrega a usos el total de cada carpeta
+func (sb *SuperBlock) diskUsage(path string, inodeIndex int32, ruta string, vistos map[int32]bool, usos *[]DiskUsage, uid int32, gid int32) (int32, error) {
+	if vistos[inodeIndex] {
+		return 0, nil
+	}
+	vistos[inodeIndex] = true
+
+	inode := &Inode{}
+	err := inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		return 0, err
+	}
+	datos, apuntadores, err := sb.walkInodeBlocks(path, inode)
+	if err != nil {
+		return 0, err
+	}
+	total := int32(len(datos) + len(apuntadores))
+
+	// Los archivos y los enlaces simbolicos solo usan sus propios bloques
+	if inode.I_type[0] != '0' || !inode.HasPermission(uid, gid, PermRead) || !inode.HasPermission(uid, gid, PermExec) {
+		return total, nil
+	}
+
+	entradas, err := sb.GetFolderEntries(path, inodeIndex)
+	if err != nil {
+		return 0, err
+	}
+	for _, content := range entradas {
+		hija := strings.TrimSuffix(ruta, "/") + "/" + content.GetName()
+		bloques, err := sb.diskUsage(path, content.B_inodo, hija, vistos, usos, uid, gid)
+		if err != nil {
+			return 0, err
+		}
+		total += bloques
+	}
+
+	*usos = append(*usos, DiskUsage{Ruta: ruta, Bloques: total})
+	return total, nil
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 )
 
@@ -62,7 +64,7 @@ func (ebr *EBR) DeserializeEBR(path string, position int32) error {
 
 	// Leer solo la cantidad de bytes que corresponden al tamaño de la estructura EBR
 	buffer := make([]byte, ebrSize)
-	_, err = file.Read(buffer)
+	_, err = io.ReadFull(file, buffer)
 	if err != nil {
 		return err
 	}
@@ -93,38 +95,52 @@ func (ebr *EBR) PrintEBR() {
 	fmt.Println()
 }
 
-// Método para obtener la primera partición disponible del ebr
-func (ebr *EBR) GetFirstAvailablePartition(path string, name string) (*EBR, int) {
-	// Calcular el offset para el start de la partición
-	offset := binary.Size(ebr) // Tamaño del EBR en bytes
-	offset += int(ebr.Ebr_start)
-	currentEBR := *ebr // Copia del EBR inicial para no modificar el original
+// Método para obtener la posicion donde se debe de crear el EBR de una nueva particion logica
+// El espacio se elige según el ajuste de la particion extendida, retorna -1 si no hay espacio
+func GetFirstAvailableLogical(cadena []EBR, extendida *PARTITION, size int32) int32 {
+	libres := GetLogicalFreeSpaces(extendida, cadena)
+	// El espacio debe de alcanzar para el EBR y para la partición
+	return SelectFreeSpace(libres, size+int32(binary.Size(EBR{})), extendida.Part_fit[0])
+}
 
-	// Recorrer las particiones del MBR
-	for {
-		//Comprovamos que no exista ya el nombre
-		// Convertir Part_name a string y eliminar los caracteres nulos
-		nombre := strings.Trim(string(currentEBR.Ebr_name[:]), "\x00 ")
-		if strings.EqualFold(nombre, name) {
-			break
+// Agrega un EBR a la cadena de la extendida y reescribe los enlaces ordenados por posicion
+// El primer EBR de la cadena siempre se conserva ya que esta al inicio de la extendida
+func InsertLogicalPartition(path string, cadena []EBR, nuevo EBR) error {
+	if len(cadena) == 0 {
+		return fmt.Errorf("la particion extendida no tiene EBR inicial")
+	}
+
+	var nodos []EBR
+	// Si el nuevo EBR inicia donde esta el primero, lo reemplaza
+	if nuevo.Ebr_start != cadena[0].Ebr_start {
+		nodos = append(nodos, cadena[0])
+	}
+	for _, actual := range cadena[1:] {
+		// Los EBR vacios que quedaban al final de la cadena ya no se necesitan
+		if !actual.IsEmpty() {
+			nodos = append(nodos, actual)
 		}
-		// Si el next de la partición es -1, entonces está disponible
-		if currentEBR.Ebr_next == -1 {
-			// Devolver la partición, el offset y el índice
-			return &currentEBR, offset
+	}
+	nodos = append(nodos, nuevo)
+
+	// Ordenar por posicion dentro del disco
+	sort.Slice(nodos, func(i, j int) bool {
+		return nodos[i].Ebr_start < nodos[j].Ebr_start
+	})
+
+	// Enlazar cada EBR con el siguiente y guardarlos
+	for i := range nodos {
+		if i+1 < len(nodos) {
+			nodos[i].Ebr_next = nodos[i+1].Ebr_start
 		} else {
-			// Calcular el nuevo offset para la siguiente partición, es decir, sumar el tamaño de la partición
-			offset += int(currentEBR.Ebr_size)
-			//Se deserealiza el sigueinte EBR
-			err := currentEBR.DeserializeEBR(path, currentEBR.Ebr_next)
-			if err != nil { //Validamos si no retorna error al deserealizar
-				fmt.Println("Error deserializando el EBR:", err)
-				return nil, -1
-			}
-			offset += binary.Size(currentEBR) // Tamaño del EBR
+			nodos[i].Ebr_next = -1
+		}
+		err := nodos[i].SerializeEBR(path, nodos[i].Ebr_start)
+		if err != nil {
+			return err
 		}
 	}
-	return nil, -2
+	return nil
 }
 
 // Crear una partición con los parámetros proporcionados
@@ -171,3 +187,63 @@ func (ebr *EBR) PrintParticiones(path string) {
 		}
 	}
 }
+
+// Retorna true si el EBR no describe ninguna partición lógica (EBR vacío o de cierre)
+func (ebr *EBR) IsEmpty() bool {
+	return ebr.Ebr_size <= 0
+}
+
+// Obtiene el nombre del EBR sin caracteres nulos
+func (ebr *EBR) GetName() string {
+	return strings.Trim(string(ebr.Ebr_name[:]), "\x00 ")
+}
+
+// Método para obtener todos los EBR de la cadena que inicia en la posición indicada
+// Se detiene si el siguiente EBR no avanza en el disco, para evitar ciclos
+func GetEBRChain(path string, position int32) ([]EBR, error) {
+	var chain []EBR
+	for position != -1 {
+		var ebr EBR
+		err := ebr.DeserializeEBR(path, position)
+		if err != nil {
+			return chain, err
+		}
+		chain = append(chain, ebr)
+
+		// El siguiente EBR siempre debe de estar despues del actual
+		if ebr.Ebr_next <= position {
+			break
+		}
+		position = ebr.Ebr_next
+	}
+	return chain, nil
+}
+
+// Busca la partición lógica con el nombre indicado dentro de la cadena de EBRs
+func GetLogicalByName(cadena []EBR, name string) *EBR {
+	inputName := strings.Trim(name, "\x00 ")
+	for i := range cadena {
+		if cadena[i].IsEmpty() {
+			continue
+		}
+		if strings.EqualFold(cadena[i].GetName(), inputName) {
+			return &cadena[i]
+		}
+	}
+	return nil
+}
+
+// Convierte la partición lógica en una PARTITION para usarla igual que una primaria
+// El sistema de archivos inicia justo despues del EBR
+func (ebr *EBR) ToPartition(id string) *PARTITION {
+	partition := &PARTITION{
+		Part_status: ebr.Ebr_mount,
+		Part_type:   [1]byte{'L'},
+		Part_fit:    ebr.Ebr_fit,
+		Part_start:  ebr.Ebr_start + int32(binary.Size(ebr)),
+		Part_size:   ebr.Ebr_size,
+		Part_name:   ebr.Ebr_name,
+	}
+	copy(partition.Part_id[:], id)
+	return partition
+}
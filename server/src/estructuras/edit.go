@@ -0,0 +1,36 @@
+package structures
+
+import "fmt"
+
+// EditFile reemplaza el contenido del archivo en la ruta indicada, si agregar es true se escribe al final
+// El usuario debe de tener permiso de escritura sobre el archivo, el superbloque lo serializa quien llama
+func (sb *SuperBlock) EditFile(path string, parentsDir []string, name string, contenido string, agregar bool, uid int32, gid int32) error {
+	// Se resuelve la ruta completa desde la raíz
+	inodeIndex, err := sb.ResolvePath(path, append(parentsDir, name), uid, gid)
+	if err != nil {
+		return err
+	}
+
+	inode := &Inode{}
+	err = inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		return err
+	}
+	if inode.I_type[0] != '1' {
+		return fmt.Errorf("error %s no es un archivo", name)
+	}
+	if !inode.HasPermission(uid, gid, PermWrite) {
+		return fmt.Errorf("error no tiene permiso de escritura sobre %s", name)
+	}
+
+	if agregar {
+		anterior, err := sb.ReadFileContent(path, inode)
+		if err != nil {
+			return err
+		}
+		contenido = anterior + contenido
+	}
+
+	// Se reescriben los bloques del archivo, actualiza I_size e I_mtime
+	return sb.WriteFileContent(path, inodeIndex, inode, contenido)
+}
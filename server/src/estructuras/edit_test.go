@@ -0,0 +1,68 @@
+package structures
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEditReplacesAndAppends(t *testing.T) {
+	sb, path := newTestFilesystem(t, 32, 64)
+
+	err := sb.CreateFile(false, path, nil, "nota.txt", "hola", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pruebas := []struct {
+		contenido string
+		agregar   bool
+		want      string
+	}{
+		{"adios", false, "adios"},
+		{" mundo", true, "adios mundo"},
+		{strings.Repeat("x", 200), true, "adios mundo" + strings.Repeat("x", 200)},
+		{"", false, ""},
+	}
+	for _, prueba := range pruebas {
+		err := sb.EditFile(path, nil, "nota.txt", prueba.contenido, prueba.agregar, RootUID, RootUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		leido, err := sb.GetFileContent(path, nil, "nota.txt", RootUID, RootUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if leido != prueba.want {
+			t.Errorf("contenido %q, se esperaba %q", leido, prueba.want)
+		}
+		assertConsistent(t, sb, path)
+	}
+}
+
+func TestEditRequiresWritePermission(t *testing.T) {
+	sb, path := newTestFilesystem(t, 32, 64)
+
+	err := sb.CreateFile(false, path, nil, "nota.txt", "hola", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.EditFile(path, nil, "nota.txt", "otro", false, 2, 2)
+	if err == nil {
+		t.Fatal("se esperaba un error sin permiso de escritura")
+	}
+	err = sb.CreateFolder(false, path, nil, "docs", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.EditFile(path, nil, "docs", "otro", false, RootUID, RootUID)
+	if err == nil {
+		t.Fatal("se esperaba un error al editar una carpeta")
+	}
+
+	leido, err := sb.GetFileContent(path, nil, "nota.txt", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leido != "hola" {
+		t.Errorf("contenido %q, se esperaba %q", leido, "hola")
+	}
+}
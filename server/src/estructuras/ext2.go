@@ -1,986 +1,409 @@
 package structures
 
 import (
-	utils "bakend/src/utils"
 	"errors"
 	"fmt"
 	"strings"
 	"time"
 )
 
+// Posición en el disco del inodo con el índice indicado
+func (sb *SuperBlock) InodeOffset(index int32) int64 {
+	return int64(sb.S_inode_start) + int64(index)*int64(sb.S_inode_size)
+}
+
+// Posición en el disco del bloque con el índice indicado
+func (sb *SuperBlock) BlockOffset(index int32) int64 {
+	return int64(sb.S_block_start) + int64(index)*int64(sb.S_block_size)
+}
+
 // Crear users.txt en nuestro sistema de archivos
 func (sb *SuperBlock) CreateUsersFile(path string) error {
 	// ----------- Creamos / -----------
-	// Creamos el inodo raíz
-	rootInode := &Inode{
-		I_uid:   1,
-		I_gid:   1,
-		I_size:  0,
-		I_atime: float32(time.Now().Unix()),
-		I_ctime: float32(time.Now().Unix()),
-		I_mtime: float32(time.Now().Unix()),
-		I_block: [15]int32{sb.S_blocks_count, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
-		I_type:  [1]byte{'0'},
-		I_perm:  [3]byte{'7', '7', '7'},
-	}
-
-	// Serializar el inodo raíz
-	err := rootInode.Serialize(path, int64(sb.S_first_ino))
+	// El inodo raíz es su propio padre
+	rootIndex, err := sb.newFolderInode(path, -1, [3]byte{'7', '7', '7'}, RootUID, RootUID)
 	if err != nil {
 		return err
 	}
 
-	// Actualizar el bitmap de inodos
-	err = sb.UpdateBitmapInode(path)
-	if err != nil {
-		return err
-	}
-
-	// Actualizar el superbloque
-	sb.S_inodes_count++
-	sb.S_free_inodes_count--
-	sb.S_first_ino += sb.S_inode_size
-
-	// Creamos el bloque del Inodo Raíz
-	rootBlock := &FolderBlock{
-		B_content: [4]FolderContent{
-			{B_name: [12]byte{'.'}, B_inodo: 0},
-			{B_name: [12]byte{'.', '.'}, B_inodo: 0},
-			{B_name: [12]byte{'-'}, B_inodo: -1},
-			{B_name: [12]byte{'-'}, B_inodo: -1},
-		},
-	}
-
-	// Actualizar el bitmap de bloques
-	err = sb.UpdateBitmapBlock(path)
-	if err != nil {
-		return err
-	}
-
-	// Serializar el bloque de carpeta raíz
-	err = rootBlock.Serialize(path, int64(sb.S_first_blo))
-	if err != nil {
-		return err
-	}
-
-	// Actualizar el superbloque
-	sb.S_blocks_count++
-	sb.S_free_blocks_count--
-	sb.S_first_blo += sb.S_block_size
-
 	// ----------- Creamos /users.txt -----------
 	usersText := "1,G,root\n1,U,root,root,123\n"
 
-	// Deserializar el inodo raíz
-	err = rootInode.Deserialize(path, int64(sb.S_inode_start+0)) // 0 porque es el inodo raíz
+	usersIndex, err := sb.newFileInode(path, usersText, [3]byte{'7', '7', '7'}, RootUID, RootUID)
 	if err != nil {
 		return err
 	}
 
-	// Actualizamos el inodo raíz
-	rootInode.I_atime = float32(time.Now().Unix())
+	// Agregamos users.txt a la carpeta raíz
+	return sb.addFolderEntry(path, rootIndex, "users.txt", usersIndex)
+}
 
-	// Serializar el inodo raíz
-	err = rootInode.Serialize(path, int64(sb.S_inode_start+0)) // 0 porque es el inodo raíz
+// newFolderInode crea el inodo de una carpeta junto con su primer bloque (. y ..) y retorna su índice
+// Si parentIndex es -1 la carpeta es su propio padre (carpeta raíz), uid y gid son el propietario
+func (sb *SuperBlock) newFolderInode(path string, parentIndex int32, perm [3]byte, uid int32, gid int32) (int32, error) {
+	// Reservar el inodo y el bloque en los bitmaps
+	inodeIndex, err := sb.AllocateInode(path)
 	if err != nil {
-		return err
+		return -1, err
 	}
-
-	// Deserializar el bloque de carpeta raíz
-	err = rootBlock.Deserialize(path, int64(sb.S_block_start+0)) // 0 porque es el bloque de carpeta raíz
+	blockIndex, err := sb.AllocateBlock(path)
 	if err != nil {
-		return err
+		return -1, sb.releaseNewInode(path, inodeIndex, nil, err)
 	}
-
-	// Actualizamos el bloque de carpeta raíz
-	rootBlock.B_content[2] = FolderContent{B_name: [12]byte{'u', 's', 'e', 'r', 's', '.', 't', 'x', 't'}, B_inodo: sb.S_inodes_count}
-
-	// Serializar el bloque de carpeta raíz
-	err = rootBlock.Serialize(path, int64(sb.S_block_start+0)) // 0 porque es el bloque de carpeta raíz
-	if err != nil {
-		return err
+	if parentIndex == -1 {
+		parentIndex = inodeIndex
 	}
 
-	// Creamos el inodo users.txt
-	usersInode := &Inode{
-		I_uid:   1,
-		I_gid:   1,
-		I_size:  int32(len(usersText)),
-		I_atime: float32(time.Now().Unix()),
-		I_ctime: float32(time.Now().Unix()),
-		I_mtime: float32(time.Now().Unix()),
-		I_block: [15]int32{sb.S_blocks_count, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
-		I_type:  [1]byte{'1'},
-		I_perm:  [3]byte{'7', '7', '7'},
+	// Crear el inodo de la carpeta
+	ahora := time.Now().Unix()
+	folderInode := &Inode{
+		I_uid:   uid,
+		I_gid:   gid,
+		I_size:  0,
+		I_atime: ahora,
+		I_ctime: ahora,
+		I_mtime: ahora,
+		I_block: [15]int32{blockIndex, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		I_type:  [1]byte{'0'},
+		I_perm:  perm,
+		I_links: 1,
 	}
 
-	// Actualizar el bitmap de inodos
-	err = sb.UpdateBitmapInode(path)
+	// Serializar el inodo de la carpeta
+	err = folderInode.Serialize(path, sb.InodeOffset(inodeIndex))
 	if err != nil {
-		return err
+		return -1, sb.releaseNewInode(path, inodeIndex, folderInode, err)
 	}
 
-	// Serializar el inodo users.txt
-	err = usersInode.Serialize(path, int64(sb.S_first_ino))
+	// Crear el bloque de la carpeta
+	folderBlock := sb.newFolderBlock(inodeIndex, parentIndex)
+
+	// Serializar el bloque de la carpeta
+	err = folderBlock.Serialize(path, sb.BlockOffset(blockIndex))
 	if err != nil {
-		return err
+		return -1, sb.releaseNewInode(path, inodeIndex, folderInode, err)
 	}
 
-	// Actualizamos el superbloque
-	sb.S_inodes_count++
-	sb.S_free_inodes_count--
-	sb.S_first_ino += sb.S_inode_size
+	return inodeIndex, nil
+}
 
-	// Creamos el bloque de users.txt
-	usersBlock := &FileBlock{
-		B_content: [64]byte{},
-	}
-	// Copiamos el texto de usuarios en el bloque
-	copy(usersBlock.B_content[:], usersText)
+// newFileInode crea el inodo de un archivo con su contenido y retorna su índice, uid y gid son el propietario
+func (sb *SuperBlock) newFileInode(path string, contenido string, perm [3]byte, uid int32, gid int32) (int32, error) {
+	return sb.newDataInode(path, '1', contenido, perm, uid, gid)
+}
 
-	// Serializar el bloque de users.txt
-	err = usersBlock.Serialize(path, int64(sb.S_first_blo))
-	if err != nil {
-		return err
+// newDataInode crea un inodo de tipo archivo o enlace simbolico, el contenido se guarda en sus bloques
+func (sb *SuperBlock) newDataInode(path string, tipo byte, contenido string, perm [3]byte, uid int32, gid int32) (int32, error) {
+	// Crear el inodo del archivo
+	ahora := time.Now().Unix()
+	fileInode := &Inode{
+		I_uid:   uid,
+		I_gid:   gid,
+		I_size:  0,
+		I_atime: ahora,
+		I_ctime: ahora,
+		I_mtime: ahora,
+		I_block: [15]int32{-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		I_type:  [1]byte{tipo},
+		I_perm:  perm,
+		I_links: 1,
 	}
 
-	// Actualizar el bitmap de bloques
-	err = sb.UpdateBitmapBlock(path)
+	// Escribir el contenido en los bloques del archivo, si no cabe no se reserva el inodo
+	err := sb.writeFileBlocks(path, fileInode, contenido)
 	if err != nil {
-		return err
+		return -1, sb.releaseNewInode(path, -1, fileInode, err)
 	}
 
-	// Actualizamos el superbloque
-	sb.S_blocks_count++
-	sb.S_free_blocks_count--
-	sb.S_first_blo += sb.S_block_size
-
-	return nil
-}
-
-// createFolderInInode crea una carpeta en un inodo específico
-func (sb *SuperBlock) createFolderInInode(path string, inodeIndex int32, destDir string) error {
-	// Crear un nuevo inodo
-	inode := &Inode{}
-	// Deserializar el inodo
-	err := inode.Deserialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
+	// Reservar el inodo en el bitmap
+	inodeIndex, err := sb.AllocateInode(path)
 	if err != nil {
-		return err
+		return -1, sb.releaseNewInode(path, -1, fileInode, err)
 	}
 
-	// Crear un nuevo bloque de carpeta
-	block := &FolderBlock{}
-
-	// Deserializar el bloque
-	err = block.Deserialize(path, int64(sb.S_block_start+(inode.I_block[0]*sb.S_block_size))) // 64 porque es el tamaño de un bloque
+	// Serializar el inodo del archivo
+	err = fileInode.Serialize(path, sb.InodeOffset(inodeIndex))
 	if err != nil {
-		return err
+		return -1, sb.releaseNewInode(path, inodeIndex, fileInode, err)
 	}
 
-	// obtenemos la posicion 2 del bloque (la que contiene el apuntador al padre)
-	first_content := block.B_content[1]
-
-	// obtenemos el numero de inode del padre
-	father_inode := first_content.B_inodo
-
-	// Iterar sobre cada bloque del inodo (apuntadores)
-	for i := 0; i < len(inode.I_block); i++ {
-		// Si el bloque no existe, se debe de crear
-		if inode.I_block[i] == -1 {
-			inode.I_block[i] = sb.S_blocks_count
-
-			new_folderblock := &FolderBlock{
-				B_content: [4]FolderContent{
-					{B_name: [12]byte{'.'}, B_inodo: inodeIndex},
-					{B_name: [12]byte{'.', '.'}, B_inodo: father_inode},
-					{B_name: [12]byte{'-'}, B_inodo: sb.S_blocks_count},
-					{B_name: [12]byte{'-'}, B_inodo: -1},
-				},
-			}
-
-			// creamos una instancia de foldercontent
-			content_folder := &FolderContent{}
-
-			// obtenemos el tercer campo del mismo (el primer espacio disponible)
-			content_folder = &new_folderblock.B_content[2]
-
-			// copiamos el nombre del archivo
-			copy(content_folder.B_name[:], []byte(destDir))
-
-			// serializar el bloque
-			err = new_folderblock.Serialize(path, int64(sb.S_first_blo))
-			if err != nil {
-				return err
-			}
-
-			// Actualizar el bitmap de inodos
-			err = sb.UpdateBitmapBlock(path)
-			if err != nil {
-				return err
-			}
-
-			// Actualizar el superbloque
-			sb.S_blocks_count++
-			sb.S_free_blocks_count--
-			sb.S_first_blo += sb.S_block_size
-
-			// Actualizamos el inode root
-			inode.I_atime = float32(time.Now().Unix())
-
-			// serializamos el inode root
-			err = inode.Serialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
-			if err != nil {
-				return err
-			}
-
-			/*
-				una vez creado el nuevo bloque y asignado al inode
-				creamos el nuevo inode y lo guardaremos en el bloque
-			*/
-
-			new_Inode := &Inode{
-				I_uid:   1,
-				I_gid:   1,
-				I_size:  0,
-				I_atime: float32(time.Now().Unix()),
-				I_ctime: float32(time.Now().Unix()),
-				I_mtime: float32(time.Now().Unix()),
-				I_block: [15]int32{sb.S_blocks_count, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
-				I_type:  [1]byte{'0'},
-				I_perm:  [3]byte{'6', '6', '4'},
-			}
-
-			//Serializa el superbloque
-			err = new_Inode.Serialize(path, int64(sb.S_first_ino))
-			if err != nil {
-				return err
-			}
-
-			// actualizamos el bitmap de inodes
-			err = sb.UpdateBitmapInode(path)
-			if err != nil {
-				return err
-			}
-
-			// Actualizar el superbloque
-			sb.S_inodes_count++
-			sb.S_free_inodes_count--
-			sb.S_first_ino += sb.S_inode_size
-
-			// creamos el bloque del inodo recien creado
-			newInode_folderblock := &FolderBlock{
-				B_content: [4]FolderContent{
-					{B_name: [12]byte{'.'}, B_inodo: sb.S_inodes_count - 1},
-					{B_name: [12]byte{'.', '.'}, B_inodo: inodeIndex},
-					{B_name: [12]byte{'-'}, B_inodo: -1},
-					{B_name: [12]byte{'-'}, B_inodo: -1},
-				},
-			}
+	return inodeIndex, nil
+}
 
-			// serializamos el bloque de la carpeta
-			err = newInode_folderblock.Serialize(path, int64(sb.S_first_blo))
-			if err != nil {
-				return err
-			}
+// Libera lo que se reservo para un inodo nuevo cuya creación fallo y retorna el error original
+// inodeIndex es -1 si el inodo aun no se reservo, inode tiene los bloques que ya se le asignaron
+func (sb *SuperBlock) releaseNewInode(path string, inodeIndex int32, inode *Inode, err error) error {
+	if inode != nil {
+		errLiberar := sb.FreeInodeBlocks(path, inode)
+		if errLiberar != nil {
+			return fmt.Errorf("%w, no se pudieron liberar los bloques: %v", err, errLiberar)
+		}
+	}
+	if inodeIndex != -1 {
+		errLiberar := sb.FreeInode(path, inodeIndex)
+		if errLiberar != nil {
+			return fmt.Errorf("%w, no se pudo liberar el inodo %d: %v", err, inodeIndex, errLiberar)
+		}
+	}
+	return err
+}
 
-			// actualizar el bitmap de bloques
-			err = sb.UpdateBitmapBlock(path)
-			if err != nil {
-				return err
-			}
+// newFolderBlock crea un bloque de carpeta con . y .. y el resto de entradas libres
+func (sb *SuperBlock) newFolderBlock(inodeIndex int32, parentIndex int32) *FolderBlock {
+	folderBlock := NewFolderBlock(sb.S_block_size)
+	folderBlock.B_content[0] = FolderContent{B_name: [12]byte{'.'}, B_inodo: inodeIndex}
+	folderBlock.B_content[1] = FolderContent{B_name: [12]byte{'.', '.'}, B_inodo: parentIndex}
+	return folderBlock
+}
 
-			// Actualizar el superbloque
-			sb.S_blocks_count++
-			sb.S_free_blocks_count--
-			sb.S_first_blo += sb.S_block_size
+// writeFileBlocks reparte el contenido en bloques de S_block_size bytes y los asigna al inodo
+// Despues de los 12 directos se usan los apuntadores simple, doble y triple indirecto
+// No serializa el inodo, solo actualiza I_block e I_size
+func (sb *SuperBlock) writeFileBlocks(path string, inode *Inode, contenido string) error {
+	tamano := int(sb.S_block_size)
+	if len(contenido) > tamano*sb.MaxFileBlocks() {
+		return fmt.Errorf("el contenido es demasiado grande, maximo %d bytes", tamano*sb.MaxFileBlocks())
+	}
 
-			return nil
+	// Recorrer el string en segmentos del tamaño de un bloque
+	contador := 0
+	for inicio := 0; inicio < len(contenido); inicio += tamano {
+		fin := inicio + tamano
+		if fin > len(contenido) {
+			fin = len(contenido) // Evitar desbordamiento
 		}
 
-		//De lo contrario continua con la creacion en la posicion dada
-		// Crear un nuevo bloque de carpeta
-		block := &FolderBlock{}
-
-		// Deserializar el bloque
-		err := block.Deserialize(path, int64(sb.S_block_start+(inode.I_block[0]*sb.S_block_size))) // 64 porque es el tamaño de un bloque
+		// Reservar el bloque en el bitmap
+		blockIndex, err := sb.AllocateBlock(path)
 		if err != nil {
 			return err
 		}
 
-		// Iterar sobre cada contenido del bloque, desde el index 2 porque los primeros dos son . y ..
-		for indexContent := 2; indexContent < len(block.B_content); indexContent++ {
-			// Obtener el contenido del bloque
-			content := block.B_content[indexContent]
-
-			//fmt.Println("---------ESTOY  CREANDO--------")
-
-			// Si el apuntador al inodo está ocupado, continuar con el siguiente
-			if content.B_inodo != -1 {
-				continue
-			}
-
-			// Actualizar el contenido del bloque
-			copy(content.B_name[:], destDir)
-			content.B_inodo = sb.S_inodes_count
-
-			// Actualizar el bloque
-			block.B_content[indexContent] = content
-
-			// Serializar el bloque
-			err = block.Serialize(path, int64(sb.S_block_start+(inode.I_block[0]*sb.S_block_size)))
-			if err != nil {
-				return err
-			}
-
-			// Crear el inodo de la carpeta
-			folderInode := &Inode{
-				I_uid:   1,
-				I_gid:   1,
-				I_size:  0,
-				I_atime: float32(time.Now().Unix()),
-				I_ctime: float32(time.Now().Unix()),
-				I_mtime: float32(time.Now().Unix()),
-				I_block: [15]int32{sb.S_blocks_count, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
-				I_type:  [1]byte{'0'},
-				I_perm:  [3]byte{'6', '6', '4'},
-			}
-
-			// Serializar el inodo de la carpeta
-			err = folderInode.Serialize(path, int64(sb.S_first_ino))
-			if err != nil {
-				return err
-			}
-
-			// Actualizar el bitmap de inodos
-			err = sb.UpdateBitmapInode(path)
-			if err != nil {
-				return err
-			}
-
-			// Actualizar el superbloque
-			sb.S_inodes_count++
-			sb.S_free_inodes_count--
-			sb.S_first_ino += sb.S_inode_size
-
-			// Crear el bloque de la carpeta
-			folderBlock := &FolderBlock{
-				B_content: [4]FolderContent{
-					{B_name: [12]byte{'.'}, B_inodo: content.B_inodo},
-					{B_name: [12]byte{'.', '.'}, B_inodo: inodeIndex},
-					{B_name: [12]byte{'-'}, B_inodo: -1},
-					{B_name: [12]byte{'-'}, B_inodo: -1},
-				},
-			}
-
-			// Serializar el bloque de la carpeta
-			err = folderBlock.Serialize(path, int64(sb.S_first_blo))
-			if err != nil {
-				return err
-			}
-
-			// Actualizar el bitmap de bloques
-			err = sb.UpdateBitmapBlock(path)
-			if err != nil {
-				return err
-			}
-
-			// Actualizar el superbloque
-			sb.S_blocks_count++
-			sb.S_free_blocks_count--
-			sb.S_first_blo += sb.S_block_size
-
-			return nil
+		// Copiamos la parte del contenido en el bloque
+		fileBlock := NewFileBlock(sb.S_block_size)
+		copy(fileBlock.B_content[:], contenido[inicio:fin])
 
+		// Serializar el bloque
+		err = fileBlock.Serialize(path, sb.BlockOffset(blockIndex))
+		if err != nil {
+			return err
 		}
 
+		err = sb.SetInodeBlock(path, inode, contador, blockIndex)
+		if err != nil {
+			return err
+		}
+		contador++
 	}
-	return errors.New("error no se pudo obtener el bloque del inodo")
+
+	inode.I_size = int32(len(contenido))
+	return nil
 }
 
-// createFileInInode crea una archivo en un inodo específico
-func (sb *SuperBlock) createFileInInode(path string, inodeIndex int32, nombreArchivo string, contenido string) error {
-	// Crear un nuevo inodo
-	inode := &Inode{}
-	// Deserializar el inodo
-	err := inode.Deserialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
+// addFolderEntry agrega la entrada name -> childIndex en la carpeta parentIndex
+// Si los bloques de la carpeta estan llenos se crea un nuevo bloque de carpeta, usando los indirectos si hace falta
+func (sb *SuperBlock) addFolderEntry(path string, parentIndex int32, name string, childIndex int32) error {
+	err := sb.ValidateName(name)
 	if err != nil {
 		return err
 	}
 
-	// creamos un instancia de folderblock
-	firstblock := &FolderBlock{}
-
-	// deserializamos el contenido del primer bloque
-	err = firstblock.Deserialize(path, int64(sb.S_block_start+(inode.I_block[0]*sb.S_block_size)))
+	// Deserializar el inodo de la carpeta
+	inode := &Inode{}
+	err = inode.Deserialize(path, sb.InodeOffset(parentIndex))
 	if err != nil {
 		return err
 	}
 
-	// obtenemos la posicion 2 del bloque (la que contiene el apuntador al padre)
-	first_content := firstblock.B_content[1]
-
-	// obtenemos el numero de inode del padre
-	father_inode := first_content.B_inodo
-
-	// Iterar sobre cada bloque del inodo (apuntadores)
-	for i := 0; i < len(inode.I_block); i++ {
-		// Si el bloque no existe, salir
-		if inode.I_block[i] == -1 {
-			inode.I_block[i] = sb.S_blocks_count
-
-			new_folderblock := &FolderBlock{
-				B_content: [4]FolderContent{
-					{B_name: [12]byte{'.'}, B_inodo: inodeIndex},
-					{B_name: [12]byte{'.', '.'}, B_inodo: father_inode},
-					{B_name: [12]byte{'-'}, B_inodo: sb.S_inodes_count},
-					{B_name: [12]byte{'-'}, B_inodo: -1},
-				},
-			}
-
-			// creamos una instancia de foldercontent
-			content_folder := &FolderContent{}
-
-			// obtenemos el tercer campo del mismo (el primer espacio disponible)
-			content_folder = &new_folderblock.B_content[2]
-
-			// copiamos el nombre del archivo
-			copy(content_folder.B_name[:], []byte(nombreArchivo))
-
-			// serializar el bloque
-			err = new_folderblock.Serialize(path, int64(sb.S_first_blo))
-			if err != nil {
-				return err
-			}
-
-			// actualizamos el bitmap de bloques
-			err = sb.UpdateBitmapBlock(path)
-			if err != nil {
-				return err
-			}
-
-			// actualizamos los campos del superbloque
-			sb.S_blocks_count++
-			sb.S_free_blocks_count--
-			sb.S_first_blo += sb.S_block_size
-
-			// Actualizamos el inode root
-			inode.I_atime = float32(time.Now().Unix())
-
-			// serializamos el inode root
-			err = inode.Serialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
-			if err != nil {
-				return err
-			}
+	bloques, err := sb.GetInodeBlocks(path, inode)
+	if err != nil {
+		return err
+	}
 
-			/*
-				una vez creado el nuevo bloque y asignado al inode
-				creamos el nuevo inode y lo guardaremos en el bloque
-			*/
-
-			new_Inode := &Inode{
-				I_uid:   1,
-				I_gid:   1,
-				I_size:  int32(len(contenido)),
-				I_atime: float32(time.Now().Unix()),
-				I_ctime: float32(time.Now().Unix()),
-				I_mtime: float32(time.Now().Unix()),
-				I_block: [15]int32{sb.S_blocks_count, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
-				I_type:  [1]byte{'1'},
-				I_perm:  [3]byte{'6', '6', '4'},
-			}
+	agregado := false
 
-			//Guarda la posicion donde se serealizo el inodo
-			posicionInodo := int64(sb.S_first_ino)
-			// Serializar el inodo users.txt
-			err = new_Inode.Serialize(path, int64(sb.S_first_ino))
-			if err != nil {
-				return err
-			}
+	// Iterar sobre cada bloque de la carpeta
+	for _, blockIndex := range bloques {
+		// Deserializar el bloque
+		block := NewFolderBlock(sb.S_block_size)
+		err := block.Deserialize(path, sb.BlockOffset(blockIndex))
+		if err != nil {
+			return err
+		}
 
-			// Actualizar el bitmap de inodos
-			err = sb.UpdateBitmapInode(path)
-			if err != nil {
-				return err
-			}
+		// Buscar espacio libre para todas las partes del nombre, sin usar . y ..
+		libre := block.freeEntries(nameEntryCount(name))
+		if libre == -1 {
+			continue
+		}
 
-			// Actualizamos el superbloque
-			sb.S_inodes_count++
-			sb.S_free_inodes_count--
-			sb.S_first_ino += sb.S_inode_size
-
-			//Aca se valida si el contenido es demasiado grande para solo un FileBlock
-			if len(contenido) > 64 {
-				tamano := 64
-				contador := 0
-				// Recorrer el string en segmentos de 64 caracteres
-				for ii := 0; ii < len(contenido); ii += tamano {
-					//fmt.Println("******************")
-					fin := ii + tamano
-					if fin > len(contenido) {
-						fin = len(contenido) // Evitar desbordamiento
-					}
-
-					//TODO: validar cuando el contenido deba de aplicar los bloques indirectos
-					// Bloque Simple Indirecto: Inodo → Bloque apuntadores → bloque de datos
-					// if contador == 12 {
-
-					// 	// Bloque Doble Indirecto: Inodo → Bloque de apuntadores → Bloquede apuntadores → bloque de datos.
-					// } else if contador == 13 {
-
-					// 	// Bloque Triple Indirecto: Inodo → Bloque de apuntadores → Bloque
-					// 	// de apuntadores → Bloque de apuntadores → bloque de datos.
-					// } else if contador == 14 {
-
-					// } else {
-					//Se actuliza los apuntadores del inodo
-					inode.I_block[i]++
-					new_Inode.I_block[contador] = inode.I_block[i]
-					//Serealizar el ibloque actualizado
-					// Deserializar el inodo
-					//err := usersInode.Serialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
-					err := new_Inode.Serialize(path, posicionInodo)
-					if err != nil {
-						return err
-					}
-
-					parte := contenido[ii:fin]
-					// Creamos el bloque de users.txt
-					usersBlock := &FileBlock{
-						B_content: [64]byte{},
-					}
-					// Copiamos el texto de usuarios en el bloque
-					copy(usersBlock.B_content[:], []byte(parte))
-
-					// Serializar el bloque de users.txt
-					err = usersBlock.Serialize(path, int64(sb.S_first_blo))
-					if err != nil {
-						return err
-					}
-
-					// Actualizar el bitmap de bloques
-					err = sb.UpdateBitmapBlock(path)
-					if err != nil {
-						return err
-					}
-
-					// Actualizamos el superbloque
-					sb.S_blocks_count++
-					sb.S_free_blocks_count--
-					sb.S_first_blo += sb.S_block_size
-					//fmt.Println(parte)
-					//}
-					//Se incrementa en una unidad el contador
-					contador++
-				}
-				//Aca es unicamente un solo FileBlock
-			} else {
-				//fmt.Println("aqui miguel")
-				// Creamos el bloque de users.txt
-				usersBlock := &FileBlock{
-					B_content: [64]byte{},
-				}
-				// Copiamos el texto de usuarios en el bloque
-				copy(usersBlock.B_content[:], []byte(contenido))
-
-				// Serializar el bloque de users.txt
-				err = usersBlock.Serialize(path, int64(sb.S_first_blo))
-				if err != nil {
-					return err
-				}
-
-				// Actualizar el bitmap de bloques
-				err = sb.UpdateBitmapBlock(path)
-				if err != nil {
-					return err
-				}
-
-				// Actualizamos el superbloque
-				sb.S_blocks_count++
-				sb.S_free_blocks_count--
-				sb.S_first_blo += sb.S_block_size
-			}
+		// Actualizar el contenido del bloque
+		block.setEntry(libre, name, childIndex)
 
-			return nil
+		err = block.Serialize(path, sb.BlockOffset(blockIndex))
+		if err != nil {
+			return err
 		}
+		agregado = true
+		break
+	}
 
-		//*********************************************************Aca es cuando ya esta creado
-		// Crear un nuevo bloque de carpeta
-		block := &FolderBlock{}
+	// Si todos los bloques estan llenos se crea un nuevo bloque de carpeta con la entrada
+	if !agregado {
+		if len(bloques) >= sb.MaxFileBlocks() {
+			return errors.New("error la carpeta ya no tiene espacio para mas entradas")
+		}
 
-		// Deserializar el bloque
-		err := block.Deserialize(path, int64(sb.S_block_start+(inode.I_block[i]*sb.S_block_size))) // 64 porque es el tamaño de un bloque
+		blockIndex, err := sb.AllocateBlock(path)
 		if err != nil {
 			return err
 		}
 
-		// Iterar sobre cada contenido del bloque, desde el index 2 porque los primeros dos son . y ..
-		for indexContent := 2; indexContent < len(block.B_content); indexContent++ {
-			// Obtener el contenido del bloque
-			content := block.B_content[indexContent]
-
-			//fmt.Println("---------ESTOY  CREANDO--------")
+		// Solo el primer bloque tiene . y .., el nuevo bloque queda libre para el nombre
+		newBlock := NewFolderBlock(sb.S_block_size)
+		newBlock.setEntry(0, name, childIndex)
 
-			// Si el apuntador al inodo está ocupado, continuar con el siguiente
-			if content.B_inodo != -1 {
-				continue
+		err = newBlock.Serialize(path, sb.BlockOffset(blockIndex))
+		if err == nil {
+			// El nuevo bloque va despues del último, en los directos o en los indirectos
+			err = sb.SetInodeBlock(path, inode, len(bloques), blockIndex)
+		}
+		if err != nil {
+			// El bloque no quedo en la carpeta, se libera
+			if errLiberar := sb.FreeBlock(path, blockIndex); errLiberar != nil {
+				return fmt.Errorf("%w, no se pudo liberar el bloque %d: %v", err, blockIndex, errLiberar)
 			}
+			return err
+		}
+	}
 
-			// Actualizar el contenido del bloque
-			copy(content.B_name[:], nombreArchivo)
-			content.B_inodo = sb.S_inodes_count
-
-			// Actualizar el bloque
-			block.B_content[indexContent] = content
+	// Actualizamos la carpeta padre, cambio su contenido
+	inode.touchModify()
+	return inode.Serialize(path, sb.InodeOffset(parentIndex))
+}
 
-			// Serializar el bloque
-			err = block.Serialize(path, int64(sb.S_block_start+(inode.I_block[i]*sb.S_block_size)))
-			if err != nil {
-				return err
-			}
+// createFolderInInode crea una carpeta dentro del inodo inodeIndex y retorna el índice del nuevo inodo
+// La carpeta pertenece al usuario uid y al grupo gid
+func (sb *SuperBlock) createFolderInInode(path string, inodeIndex int32, destDir string, uid int32, gid int32) (int32, error) {
+	// El nombre se valida antes de reservar el inodo
+	err := sb.ValidateName(destDir)
+	if err != nil {
+		return -1, err
+	}
 
-			// Creamos el inodo nombre.txt
-			usersInode := &Inode{
-				I_uid:   1,
-				I_gid:   1,
-				I_size:  int32(len(contenido)),
-				I_atime: float32(time.Now().Unix()),
-				I_ctime: float32(time.Now().Unix()),
-				I_mtime: float32(time.Now().Unix()),
-				I_block: [15]int32{sb.S_blocks_count, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
-				I_type:  [1]byte{'1'},
-				I_perm:  [3]byte{'6', '6', '4'},
-			}
+	// Crear el inodo de la carpeta con su primer bloque, con 775 el propietario y su grupo la pueden recorrer
+	folderIndex, err := sb.newFolderInode(path, inodeIndex, [3]byte{'7', '7', '5'}, uid, gid)
+	if err != nil {
+		return -1, err
+	}
 
-			// Actualizar el bitmap de inodos
-			err = sb.UpdateBitmapInode(path)
-			if err != nil {
-				return err
-			}
+	// Agregar la carpeta al padre, si no hay espacio la carpeta nueva se libera
+	err = sb.addFolderEntry(path, inodeIndex, destDir, folderIndex)
+	if err != nil {
+		return -1, sb.releaseUnlinked(path, folderIndex, err)
+	}
 
-			//Guarda la posicion donde se serealizo el inodo
-			posicionInodo := int64(sb.S_first_ino)
-			// Serializar el inodo users.txt
-			err = usersInode.Serialize(path, int64(sb.S_first_ino))
-			if err != nil {
-				return err
-			}
+	return folderIndex, nil
+}
 
-			// Actualizamos el superbloque
-			sb.S_inodes_count++
-			sb.S_free_inodes_count--
-			sb.S_first_ino += sb.S_inode_size
-
-			//Aca se valida si el contenido es demasiado grande para solo un FileBlock
-			if len(contenido) > 64 {
-				tamano := 64
-				contador := 0
-				// Recorrer el string en segmentos de 64 caracteres
-				for iii := 0; iii < len(contenido); iii += tamano {
-					//fmt.Println("******************")
-					fin := iii + tamano
-					if fin > len(contenido) {
-						fin = len(contenido) // Evitar desbordamiento
-					}
-
-					//TODO: validar cuando el contenido deba de aplicar los bloques indirectos
-					// Bloque Simple Indirecto: Inodo → Bloque apuntadores → bloque de datos
-					// if contador == 12 {
-
-					// 	// Bloque Doble Indirecto: Inodo → Bloque de apuntadores → Bloquede apuntadores → bloque de datos.
-					// } else if contador == 13 {
-
-					// 	// Bloque Triple Indirecto: Inodo → Bloque de apuntadores → Bloque
-					// 	// de apuntadores → Bloque de apuntadores → bloque de datos.
-					// } else if contador == 14 {
-
-					// } else {
-					//Se actuliza los apuntadores del inodo
-					inode.I_block[i]++
-					usersInode.I_block[contador] = inode.I_block[i]
-					//Serealizar el ibloque actualizado
-					// Deserializar el inodo
-					//err := usersInode.Serialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
-					err := usersInode.Serialize(path, posicionInodo)
-					if err != nil {
-						return err
-					}
-
-					parte := contenido[iii:fin]
-					// Creamos el bloque de users.txt
-					usersBlock := &FileBlock{
-						B_content: [64]byte{},
-					}
-					// Copiamos el texto de usuarios en el bloque
-					copy(usersBlock.B_content[:], []byte(parte))
-
-					// Serializar el bloque de users.txt
-					err = usersBlock.Serialize(path, int64(sb.S_first_blo))
-					if err != nil {
-						return err
-					}
-
-					// Actualizar el bitmap de bloques
-					err = sb.UpdateBitmapBlock(path)
-					if err != nil {
-						return err
-					}
-
-					// Actualizamos el superbloque
-					sb.S_blocks_count++
-					sb.S_free_blocks_count--
-					sb.S_first_blo += sb.S_block_size
-					//fmt.Println(parte)
-					//}
-					//Se incrementa en una unidad el contador
-					contador++
-				}
-				//Aca es unicamente un solo FileBlock
-			} else {
-				//fmt.Println("aqui miguel")
-				// Creamos el bloque de users.txt
-				usersBlock := &FileBlock{
-					B_content: [64]byte{},
-				}
-				// Copiamos el texto de usuarios en el bloque
-				copy(usersBlock.B_content[:], []byte(contenido))
-
-				// Serializar el bloque de users.txt
-				err = usersBlock.Serialize(path, int64(sb.S_first_blo))
-				if err != nil {
-					return err
-				}
-
-				// Actualizar el bitmap de bloques
-				err = sb.UpdateBitmapBlock(path)
-				if err != nil {
-					return err
-				}
-
-				// Actualizamos el superbloque
-				sb.S_blocks_count++
-				sb.S_free_blocks_count--
-				sb.S_first_blo += sb.S_block_size
-			}
+// createFileInInode crea una archivo en un inodo específico
+// El archivo pertenece al usuario uid y al grupo gid
+func (sb *SuperBlock) createFileInInode(path string, inodeIndex int32, nombreArchivo string, contenido string, uid int32, gid int32) error {
+	// El nombre se valida antes de reservar el inodo
+	err := sb.ValidateName(nombreArchivo)
+	if err != nil {
+		return err
+	}
 
-			//No retornamos nada
-			return nil
-		}
+	// Crear el inodo del archivo con su contenido
+	fileIndex, err := sb.newFileInode(path, contenido, [3]byte{'6', '6', '4'}, uid, gid)
+	if err != nil {
+		return err
+	}
 
+	// Agregar el archivo a la carpeta padre, si no hay espacio el archivo se libera
+	err = sb.addFolderEntry(path, inodeIndex, nombreArchivo, fileIndex)
+	if err != nil {
+		return sb.releaseUnlinked(path, fileIndex, err)
 	}
 	return nil
 }
 
-// createFolderInInode crea una carpeta en un inodo específico
-func (sb *SuperBlock) getContenidoFile(path string, inodeIndex int32, parentsDir []string, destDir string) (string, error) {
+// Libera el inodo recien creado y sus bloques cuando no se pudo agregar a su carpeta, retorna el error original
+func (sb *SuperBlock) releaseUnlinked(path string, inodeIndex int32, err error) error {
+	inode := &Inode{}
+	errLeer := inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+	if errLeer != nil {
+		return fmt.Errorf("%w, no se pudo liberar el inodo %d: %v", err, inodeIndex, errLeer)
+	}
+	return sb.releaseNewInode(path, inodeIndex, inode, err)
+}
+
+// obtnerDot_LS retorna las filas del reporte ls de la carpeta inodeIndex
+// Si inodeIndex es un archivo solo se muestra la fila del archivo
+func (sb *SuperBlock) obtnerDot_LS(path string, inodeIndex int32, nombre string) (string, error) {
 	// Crear un nuevo inodo
 	inode := &Inode{}
 	// Deserializar el inodo
-	err := inode.Deserialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
+	err := inode.Deserialize(path, sb.InodeOffset(inodeIndex))
 	if err != nil {
 		return "", err
 	}
-	// Verificar si el inodo es de tipo carpeta
-	//fmt.Println(inodeIndex)
-	if inode.I_type[0] == '1' {
-		//fmt.Println("El tipo es 1 (Archivo)")
-		return "", nil
-	}
-
-	// Iterar sobre cada bloque del inodo (apuntadores)
-	for _, blockIndex := range inode.I_block {
-		// Si el bloque no existe, salir
-		if blockIndex == -1 {
-			break
-		}
-
-		// Crear un nuevo bloque de carpeta
-		block := &FolderBlock{}
-
-		// Deserializar el bloque
-		err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size))) // 64 porque es el tamaño de un bloque
-		if err != nil {
-			return "", err
-		}
-
-		// Iterar sobre cada contenido del bloque, desde el index 2 porque los primeros dos son . y ..
-		for indexContent := 2; indexContent < len(block.B_content); indexContent++ {
-			// Obtener el contenido del bloque
-			content := block.B_content[indexContent]
-
-			// Sí las carpetas padre no están vacías debereamos buscar la carpeta padre más cercana
-			if len(parentsDir) != 0 {
-				//fmt.Println("---------ESTOY  VISITANDO--------")
-
-				// Si el contenido está vacío, salir
-				if content.B_inodo == -1 {
-					break
-				}
-
-				// Obtenemos la carpeta padre más cercana
-				parentDir, err := utils.First(parentsDir)
-				if err != nil {
-					return "", err
-				}
-
-				// Convertir B_name a string y eliminar los caracteres nulos
-				contentName := strings.Trim(string(content.B_name[:]), "\x00 ")
-				// Convertir parentDir a string y eliminar los caracteres nulos
-				parentDirName := strings.Trim(parentDir, "\x00 ")
-				// fmt.Println(contentName)
-				// fmt.Println(parentDirName)
-				// Si el nombre del contenido coincide con el nombre de la carpeta padre
-				if strings.EqualFold(contentName, parentDirName) {
-					//fmt.Println("---------LA ENCONTRÉ-------")
-					// Si son las mismas, entonces entramos al inodo que apunta el bloque
-					cade, err := sb.getContenidoFile(path, content.B_inodo, utils.RemoveElement(parentsDir, 0), destDir)
-					if err != nil {
-						return "", err
-					}
-					return cade, nil
-				}
-			} else {
-				//fmt.Println("---------ESTOY  OBTENIENDO--------")
-
-				//Comprovamos que sea el mismo archivo
-				contentName := strings.Trim(string(content.B_name[:]), "\x00 ")
-				// fmt.Println(contentName)
-				// fmt.Println(destDir)
-				if contentName == destDir {
-					//Aca se obtiene el inodo:
-					inode2 := &Inode{}
-					// Deserializar el inodo
-					err := inode2.Deserialize(path, int64(sb.S_inode_start+(content.B_inodo*sb.S_inode_size)))
-					if err != nil {
-						return "", err
-					}
-					//Aca se muestra el inodo
-					//inode2.Print()
-
-					contenido := ""
-					// Iterar sobre cada bloque del inodo (apuntadores)
-					for _, blockIndex2 := range inode2.I_block {
-						// Si el bloque no existe, salir
-						if blockIndex2 == -1 {
-							break
-						}
-
-						filebloque := &FileBlock{}
-
-						// Deserializar el filebloque
-						err := filebloque.Deserialize(path, int64(sb.S_block_start+(blockIndex2*sb.S_block_size))) // 64 porque es el tamaño de un bloque
-						if err != nil {
-							return "", err
-						}
-
-						contenido += strings.Trim(string(filebloque.B_content[:]), "\x00 ")
-						// fmt.Println("****************contenido******************")
-						// fmt.Println(contenido)
-						// filebloque.Print()
-					}
-					//fmt.Println(contenido)
-
-					return contenido, nil
-				}
-
-				//return "", nil
-			}
-		}
-
+	if inode.I_type[0] != '0' {
+		return sb.obtenerFilaLS(path, FolderEntry{B_inodo: inodeIndex}, nombre)
 	}
-	return "", nil
-}
 
-// createFolderInInode crea una carpeta en un inodo específico
-func (sb *SuperBlock) obtnerDot_LS(path string, inodeIndex int32) (string, error) {
-	// Crear un nuevo inodo
-	inode := &Inode{}
-	// Deserializar el inodo
-	err := inode.Deserialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
+	// Obtener las entradas de todos los bloques de la carpeta, directos e indirectos
+	entradas, err := sb.GetFolderEntries(path, inodeIndex)
 	if err != nil {
 		return "", err
 	}
 
 	//Esta es la cadena donde se almacenara el resultado
 	cadenaDot := ""
-	//inode.Print()
-	// Iterar sobre cada bloque del inodo (apuntadores)
-	for i := 0; i < len(inode.I_block); i++ {
-		// Si el bloque no existe, se debe de parar
-		if inode.I_block[i] == -1 {
-			break
-		}
-
-		//De lo contrario continua con la creacion en la posicion dada
-		// Crear un nuevo bloque de carpeta
-		block := &FolderBlock{}
-
-		// Deserializar el bloque
-		err := block.Deserialize(path, int64(sb.S_block_start+(inode.I_block[i]*sb.S_block_size))) // 64 porque es el tamaño de un bloque
+	for _, content := range entradas {
+		fila, err := sb.obtenerFilaLS(path, content, content.GetName())
 		if err != nil {
 			return "", err
 		}
+		cadenaDot += fila
+	}
 
-		// Iterar sobre cada contenido del bloque, desde el index 2 porque los primeros dos son . y ..
-		for indexContent := 2; indexContent < len(block.B_content); indexContent++ {
-			// Obtener el contenido del bloque
-			content := block.B_content[indexContent]
-
-			// Si el apuntador al inodo está ocupado, continuar con el siguiente
-			if content.B_inodo == -1 {
-				continue
-			}
+	return cadenaDot, nil
+}
 
-			//fmt.Println(content.B_inodo)
+// Genera la fila del reporte ls para la entrada indicada
+func (sb *SuperBlock) obtenerFilaLS(path string, content FolderEntry, contentName string) (string, error) {
+	//Ahora obtnego el inodo para la informacion
+	inode2 := &Inode{}
+	// Deserializar el inodo
+	err := inode2.Deserialize(path, sb.InodeOffset(content.B_inodo))
+	if err != nil {
+		return "", err
+	}
 
-			//Ahora obtnego el inodo para la informacion
-			inode2 := &Inode{}
-			// Deserializar el inodo
-			err2 := inode2.Deserialize(path, int64(sb.S_inode_start+(content.B_inodo*sb.S_inode_size)))
-			if err2 != nil {
-				return "", err2
-			}
+	//Obtnermos los permisos
+	permisos := strings.Trim(string(inode2.I_perm[:]), "\x00 ")
 
-			//Obtnermos los permisos
-			permisos := strings.Trim(string(inode2.I_perm[:]), "\x00 ")
-			//fmt.Printf("Permiso: %s", permisos)
-
-			//Mostramos el propietario
-			//fmt.Printf("Propietario: %d", inode2.I_uid)
-
-			//Mostramos el grupo propietario
-			//fmt.Printf("Grupo propietario: %d", inode2.I_gid)
-
-			//Mostramos la fecha de modificacion
-			mtime := time.Unix(int64(inode2.I_mtime), 0).Format(time.RFC3339)
-			//fmt.Printf("Fecha modificacion: %s", mtime)
-
-			//Mostramos el tipo
-			tipo := ""
-			if inode2.I_type == [1]byte{'1'} {
-				//fmt.Println("Archivo")
-				tipo = "Archivo"
-			} else {
-				//fmt.Println("Carpeta")
-				tipo = "Carpeta"
-				//inode2.Print()
-			}
+	//Mostramos la fecha de modificacion
+	mtime := time.Unix(inode2.I_mtime, 0).Format(time.RFC3339)
 
-			//Mostramos la fecha de creacion
-			ctime := time.Unix(int64(inode2.I_ctime), 0).Format(time.RFC3339)
-			//fmt.Printf("Fecha de creacion: %s", ctime)
+	//Mostramos el tipo
+	tipo := ""
+	if inode2.I_type == [1]byte{'1'} {
+		tipo = "Archivo"
+	} else if inode2.I_type == [1]byte{'2'} {
+		tipo = "Enlace"
+	} else {
+		tipo = "Carpeta"
+	}
 
-			// Convertir B_name a string y eliminar los caracteres nulos
-			contentName := strings.Trim(string(content.B_name[:]), "\x00 ")
-			//fmt.Printf("Nombre: %s", contentName)
+	//Mostramos la fecha de creacion
+	ctime := time.Unix(inode2.I_ctime, 0).Format(time.RFC3339)
 
-			cadenaDot += fmt.Sprintf(`
+	return fmt.Sprintf(`
 			<tr>
                 <td>%s</td><td>%d</td><td>%d</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td>
             </tr>
-			`, permisos, inode2.I_uid, inode2.I_gid, mtime, tipo, ctime, contentName)
-		}
-
-	}
-
-	return cadenaDot, nil
+			`, permisos, inode2.I_uid, inode2.I_gid, mtime, tipo, ctime, contentName), nil
 }
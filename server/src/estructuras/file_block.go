@@ -4,13 +4,18 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 )
 
 type FileBlock struct {
-	B_content [64]byte
-	// Total: 64 bytes
+	B_content []byte // S_block_size bytes
+}
+
+// NewFileBlock crea un bloque de archivo vacío del tamaño de bloque indicado
+func NewFileBlock(blockSize int32) *FileBlock {
+	return &FileBlock{B_content: make([]byte, blockSize)}
 }
 
 // Serialize escribe la estructura FileBlock en un archivo binario en la posición especificada
@@ -27,8 +32,8 @@ func (fb *FileBlock) Serialize(path string, offset int64) error {
 		return err
 	}
 
-	// Serializar la estructura FileBlock directamente en el archivo
-	err = binary.Write(file, binary.LittleEndian, fb)
+	// Serializar el contenido del bloque directamente en el archivo
+	err = binary.Write(file, binary.LittleEndian, fb.B_content)
 	if err != nil {
 		return err
 	}
@@ -50,22 +55,22 @@ func (fb *FileBlock) Deserialize(path string, offset int64) error {
 		return err
 	}
 
-	// Obtener el tamaño de la estructura FileBlock
-	fbSize := binary.Size(fb)
+	// Obtener el tamaño del bloque, el contenido se crea con NewFileBlock
+	fbSize := binary.Size(fb.B_content)
 	if fbSize <= 0 {
 		return fmt.Errorf("invalid FileBlock size: %d", fbSize)
 	}
 
 	// Leer solo la cantidad de bytes que corresponden al tamaño de la estructura FileBlock
 	buffer := make([]byte, fbSize)
-	_, err = file.Read(buffer)
+	_, err = io.ReadFull(file, buffer)
 	if err != nil {
 		return err
 	}
 
 	// Deserializar los bytes leídos en la estructura FileBlock
 	reader := bytes.NewReader(buffer)
-	err = binary.Read(reader, binary.LittleEndian, fb)
+	err = binary.Read(reader, binary.LittleEndian, fb.B_content)
 	if err != nil {
 		return err
 	}
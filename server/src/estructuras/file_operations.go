@@ -0,0 +1,352 @@
+package structures
+
+import (
+	"errors"
+	"fmt"
+)
+
+// RenamePath cambia el nombre del archivo o carpeta en la ruta indicada
+// El usuario debe de tener permiso de escritura sobre la carpeta que contiene el elemento
+// El superbloque lo debe de serializar quien llama
+func (sb *SuperBlock) RenamePath(path string, parentsDir []string, name string, newName string, uid int32, gid int32) error {
+	parentIndex, childIndex, err := sb.resolveEntry(path, parentsDir, name, uid, gid)
+	if err != nil {
+		return err
+	}
+	err = sb.checkPermission(path, parentIndex, parentName(parentsDir), uid, gid, PermWrite)
+	if err != nil {
+		return err
+	}
+
+	// El nuevo nombre no puede existir en la misma carpeta
+	existente, err := sb.lookupEntry(path, parentIndex, newName, uid, gid)
+	if err != nil {
+		return err
+	}
+	if existente != -1 {
+		return fmt.Errorf("error ya existe %s en la carpeta", newName)
+	}
+	err = sb.ValidateName(newName)
+	if err != nil {
+		return err
+	}
+
+	// El nuevo nombre puede ocupar otra cantidad de entradas, por eso se quita y se vuelve a agregar
+	err = sb.removeFolderEntry(path, parentIndex, name, childIndex)
+	if err != nil {
+		return err
+	}
+	err = sb.addFolderEntry(path, parentIndex, newName, childIndex)
+	if err != nil {
+		// Se restaura el nombre anterior, su espacio quedo libre
+		if errRestaurar := sb.addFolderEntry(path, parentIndex, name, childIndex); errRestaurar != nil {
+			return fmt.Errorf("%w, no se pudo restaurar %s: %v", err, name, errRestaurar)
+		}
+		return err
+	}
+	return sb.touchInodeChange(path, childIndex)
+}
+
+// Marca el cambio del inodo sin modificar su contenido, como al renombrarlo o moverlo
+func (sb *SuperBlock) touchInodeChange(path string, inodeIndex int32) error {
+	inode := &Inode{}
+	err := inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		return err
+	}
+	inode.touchChange()
+	return inode.Serialize(path, sb.InodeOffset(inodeIndex))
+}
+
+// CopyPath copia el archivo o carpeta (con todo su contenido) dentro de la carpeta destino
+// Se conserva el propietario y los permisos, los elementos sin permiso de lectura no se copian
+// El superbloque lo debe de serializar quien llama
+func (sb *SuperBlock) CopyPath(path string, parentsDir []string, name string, destino []string, uid int32, gid int32) error {
+	_, childIndex, err := sb.resolveEntry(path, parentsDir, name, uid, gid)
+	if err != nil {
+		return err
+	}
+	err = sb.checkPermission(path, childIndex, name, uid, gid, PermRead)
+	if err != nil {
+		return err
+	}
+
+	destIndex, err := sb.resolveDestination(path, destino, name, childIndex, uid, gid)
+	if err != nil {
+		return err
+	}
+
+	nuevo, err := sb.copyInode(path, childIndex, destIndex, uid, gid)
+	if err != nil {
+		return err
+	}
+	// Si la copia no se puede agregar al destino se libera completa
+	err = sb.addFolderEntry(path, destIndex, name, nuevo)
+	if err != nil {
+		return sb.releaseCopy(path, nuevo, err)
+	}
+	return nil
+}
+
+// MovePath mueve el archivo o carpeta a la carpeta destino, solo se cambian las entradas de las carpetas
+// El usuario debe de tener permiso de escritura sobre el elemento, sobre su carpeta y sobre la carpeta destino
+func (sb *SuperBlock) MovePath(path string, parentsDir []string, name string, destino []string, uid int32, gid int32) error {
+	parentIndex, childIndex, err := sb.resolveEntry(path, parentsDir, name, uid, gid)
+	if err != nil {
+		return err
+	}
+	err = sb.checkPermission(path, childIndex, name, uid, gid, PermWrite)
+	if err != nil {
+		return err
+	}
+	err = sb.checkPermission(path, parentIndex, parentName(parentsDir), uid, gid, PermWrite)
+	if err != nil {
+		return err
+	}
+
+	destIndex, err := sb.resolveDestination(path, destino, name, childIndex, uid, gid)
+	if err != nil {
+		return err
+	}
+	if destIndex == parentIndex {
+		return nil
+	}
+
+	// Primero se agrega en la carpeta destino, si falla el elemento sigue en la carpeta anterior
+	err = sb.addFolderEntry(path, destIndex, name, childIndex)
+	if err != nil {
+		return err
+	}
+	err = sb.removeFolderEntry(path, parentIndex, name, childIndex)
+	if err != nil {
+		// Se quita la entrada del destino para que no quede en las dos carpetas
+		if errRestaurar := sb.removeFolderEntry(path, destIndex, name, childIndex); errRestaurar != nil {
+			return fmt.Errorf("%w, no se pudo quitar %s del destino: %v", err, name, errRestaurar)
+		}
+		return err
+	}
+	err = sb.touchInodeChange(path, childIndex)
+	if err != nil {
+		return err
+	}
+
+	// Si es una carpeta, su .. ahora apunta a la carpeta destino
+	// Los discos anteriores tienen . y .. en todos los bloques, se actualizan todos
+	inode := &Inode{}
+	err = inode.Deserialize(path, sb.InodeOffset(childIndex))
+	if err != nil {
+		return err
+	}
+	if inode.I_type[0] != '0' {
+		return nil
+	}
+	bloques, err := sb.GetInodeBlocks(path, inode)
+	if err != nil {
+		return err
+	}
+	for _, blockIndex := range bloques {
+		block := NewFolderBlock(sb.S_block_size)
+		err := block.Deserialize(path, sb.BlockOffset(blockIndex))
+		if err != nil {
+			return err
+		}
+		if block.reservedEntries() == 0 {
+			continue
+		}
+		block.B_content[1].B_inodo = destIndex
+		err = block.Serialize(path, sb.BlockOffset(blockIndex))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Retorna el inodo de la carpeta padre y el inodo del elemento en la ruta indicada
+func (sb *SuperBlock) resolveEntry(path string, parentsDir []string, name string, uid int32, gid int32) (int32, int32, error) {
+	if name == "" {
+		return -1, -1, errors.New("error la operacion no se puede realizar sobre la carpeta raíz")
+	}
+	parentIndex, err := sb.ResolvePath(path, parentsDir, uid, gid)
+	if err != nil {
+		return -1, -1, err
+	}
+	// El último componente no se sigue, las operaciones sobre un enlace se aplican al enlace
+	childIndex, err := sb.lookupEntry(path, parentIndex, name, uid, gid)
+	if err != nil {
+		return -1, -1, err
+	}
+	if childIndex == -1 {
+		return -1, -1, fmt.Errorf("error la ruta %s no existe", name)
+	}
+	return parentIndex, childIndex, nil
+}
+
+// Nombre de la carpeta padre para los mensajes de permisos
+func parentName(parentsDir []string) string {
+	if len(parentsDir) == 0 {
+		return "/"
+	}
+	return parentsDir[len(parentsDir)-1]
+}
+
+// Resuelve la carpeta destino de copy y move y valida que se pueda agregar el elemento
+func (sb *SuperBlock) resolveDestination(path string, destino []string, name string, childIndex int32, uid int32, gid int32) (int32, error) {
+	destIndex, err := sb.ResolvePath(path, destino, uid, gid)
+	if err != nil {
+		return -1, err
+	}
+
+	destInode := &Inode{}
+	err = destInode.Deserialize(path, sb.InodeOffset(destIndex))
+	if err != nil {
+		return -1, err
+	}
+	if destInode.I_type[0] != '0' {
+		return -1, errors.New("error el destino no es una carpeta")
+	}
+	if !destInode.HasPermission(uid, gid, PermWrite) {
+		return -1, errors.New("error no tiene permiso de escritura sobre la carpeta destino")
+	}
+
+	// Una carpeta no se puede colocar dentro de si misma
+	dentro, err := sb.isInside(path, destIndex, childIndex)
+	if err != nil {
+		return -1, err
+	}
+	if dentro {
+		return -1, errors.New("error el destino esta dentro de la carpeta de origen")
+	}
+
+	existente, err := sb.lookupEntry(path, destIndex, name, uid, gid)
+	if err != nil {
+		return -1, err
+	}
+	if existente != -1 {
+		return -1, fmt.Errorf("error ya existe %s en la carpeta destino", name)
+	}
+	return destIndex, nil
+}
+
+// Valida si la carpeta inodeIndex es ancestro o es la misma carpeta folderIndex, subiendo por los ..
+func (sb *SuperBlock) isInside(path string, folderIndex int32, inodeIndex int32) (bool, error) {
+	actual := folderIndex
+	for {
+		if actual == inodeIndex {
+			return true, nil
+		}
+		if actual == 0 {
+			return false, nil
+		}
+
+		padre, err := sb.parentFolder(path, actual)
+		if err != nil {
+			return false, err
+		}
+		actual = padre
+	}
+}
+
+// Valida el permiso del usuario sobre el inodo
+func (sb *SuperBlock) checkPermission(path string, inodeIndex int32, name string, uid int32, gid int32, permiso byte) error {
+	inode := &Inode{}
+	err := inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		return err
+	}
+	if !inode.HasPermission(uid, gid, permiso) {
+		tipo := "escritura"
+		if permiso == PermRead {
+			tipo = "lectura"
+		} else if permiso == PermExec {
+			tipo = "ejecución"
+		}
+		return fmt.Errorf("error no tiene permiso de %s sobre %s", tipo, name)
+	}
+	return nil
+}
+
+// Valida que el usuario pueda recorrer la carpeta folderIndex (permiso de ejecución)
+// Si no es una carpeta no se valida, quien busca en ella retorna el error
+func (sb *SuperBlock) checkSearch(path string, folderIndex int32, uid int32, gid int32) error {
+	folder := &Inode{}
+	err := folder.Deserialize(path, sb.InodeOffset(folderIndex))
+	if err != nil {
+		return err
+	}
+	if folder.I_type[0] == '0' && !folder.HasPermission(uid, gid, PermExec) {
+		return errors.New("error no tiene permiso de ejecución sobre una carpeta de la ruta")
+	}
+	return nil
+}
+
+// Duplica el inodo srcIndex (y su contenido si es carpeta) con padre parentIndex y retorna el nuevo inodo
+// La copia conserva el propietario del original, si falla se libera todo lo que ya se copio
+func (sb *SuperBlock) copyInode(path string, srcIndex int32, parentIndex int32, uid int32, gid int32) (int32, error) {
+	src := &Inode{}
+	err := src.Deserialize(path, sb.InodeOffset(srcIndex))
+	if err != nil {
+		return -1, err
+	}
+
+	if src.I_type[0] != '0' {
+		contenido, err := sb.ReadFileContent(path, src)
+		if err != nil {
+			return -1, err
+		}
+		// Los enlaces simbolicos se copian como enlaces
+		return sb.newDataInode(path, src.I_type[0], contenido, src.I_perm, src.I_uid, src.I_gid)
+	}
+
+	nuevo, err := sb.newFolderInode(path, parentIndex, src.I_perm, src.I_uid, src.I_gid)
+	if err != nil {
+		return -1, err
+	}
+
+	entradas, err := sb.GetFolderEntries(path, srcIndex)
+	if err != nil {
+		return -1, sb.releaseCopy(path, nuevo, err)
+	}
+	for _, content := range entradas {
+		// Los elementos que el usuario no puede leer no se copian
+		hijo := &Inode{}
+		err := hijo.Deserialize(path, sb.InodeOffset(content.B_inodo))
+		if err != nil {
+			return -1, sb.releaseCopy(path, nuevo, err)
+		}
+		if !hijo.HasPermission(uid, gid, PermRead) {
+			continue
+		}
+
+		// La copia del hijo ya se libero si fallo
+		copia, err := sb.copyInode(path, content.B_inodo, nuevo, uid, gid)
+		if err != nil {
+			return -1, sb.releaseCopy(path, nuevo, err)
+		}
+		err = sb.addFolderEntry(path, nuevo, content.GetName(), copia)
+		if err != nil {
+			return -1, sb.releaseCopy(path, nuevo, sb.releaseCopy(path, copia, err))
+		}
+	}
+	return nuevo, nil
+}
+
+// Libera la copia incompleta inodeIndex con todo lo que ya se copio dentro y retorna el error original
+// Los inodos de una copia solo tienen una entrada, por eso se liberan sin revisar sus enlaces
+func (sb *SuperBlock) releaseCopy(path string, inodeIndex int32, err error) error {
+	inode := &Inode{}
+	errLeer := inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+	if errLeer != nil {
+		return fmt.Errorf("%w, no se pudo liberar el inodo %d: %v", err, inodeIndex, errLeer)
+	}
+	if inode.I_type[0] == '0' {
+		entradas, errLeer := sb.GetFolderEntries(path, inodeIndex)
+		if errLeer != nil {
+			return fmt.Errorf("%w, no se pudo liberar el inodo %d: %v", err, inodeIndex, errLeer)
+		}
+		for _, content := range entradas {
+			err = sb.releaseCopy(path, content.B_inodo, err)
+		}
+	}
+	return sb.releaseNewInode(path, inodeIndex, inode, err)
+}
@@ -0,0 +1,149 @@
+package structures
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Crea /a/sub con una subcarpeta y archivos suficientes para usar varios bloques de carpeta
+func createTestTree(t *testing.T, sb *SuperBlock, path string) {
+	t.Helper()
+
+	err := sb.CreateFolder(true, path, []string{"a", "sub"}, "interna", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.CreateFolder(false, path, nil, "b", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 4; i++ {
+		nombre := fmt.Sprintf("archivo_%d.txt", i)
+		err := sb.CreateFile(false, path, []string{"a", "sub"}, nombre, nombre, RootUID, RootUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Valida que el .. de la carpeta apunte a la carpeta padre esperada
+func assertParent(t *testing.T, sb *SuperBlock, path string, folderIndex int32, want int32) {
+	t.Helper()
+
+	padre, err := sb.parentFolder(path, folderIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if padre != want {
+		t.Errorf("el .. de la carpeta %d apunta a %d, se esperaba %d", folderIndex, padre, want)
+	}
+}
+
+func TestMoveFolderUpdatesParent(t *testing.T) {
+	sb, path := newTestFilesystem(t, 32, 64)
+	createTestTree(t, sb, path)
+	sub := mustResolve(t, sb, path, "a", "sub")
+
+	err := sb.MovePath(path, []string{"a"}, "sub", []string{"b"}, RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if movida := mustResolve(t, sb, path, "b", "sub"); movida != sub {
+		t.Fatalf("/b/sub es el inodo %d, se esperaba %d", movida, sub)
+	}
+	if _, err := sb.ResolvePath(path, []string{"a", "sub"}, RootUID, RootUID); err == nil {
+		t.Error("/a/sub no se quito de la carpeta anterior")
+	}
+	assertParent(t, sb, path, sub, mustResolve(t, sb, path, "b"))
+	assertParent(t, sb, path, mustResolve(t, sb, path, "b", "sub", "interna"), sub)
+	assertConsistent(t, sb, path)
+
+	// Una carpeta no se puede mover dentro de si misma
+	err = sb.MovePath(path, nil, "b", []string{"b", "sub"}, RootUID, RootUID)
+	if err == nil {
+		t.Error("se esperaba un error al mover una carpeta dentro de si misma")
+	}
+}
+
+func TestCopyFolderSetsParent(t *testing.T) {
+	sb, path := newTestFilesystem(t, 32, 64)
+	createTestTree(t, sb, path)
+
+	err := sb.CopyPath(path, []string{"a"}, "sub", []string{"b"}, RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := mustResolve(t, sb, path, "a", "sub")
+	copia := mustResolve(t, sb, path, "b", "sub")
+	if copia == original {
+		t.Fatal("la copia usa el mismo inodo que el original")
+	}
+	assertParent(t, sb, path, original, mustResolve(t, sb, path, "a"))
+	assertParent(t, sb, path, copia, mustResolve(t, sb, path, "b"))
+	assertParent(t, sb, path, mustResolve(t, sb, path, "b", "sub", "interna"), copia)
+
+	contenido, err := sb.GetFileContent(path, []string{"b", "sub"}, "archivo_3.txt", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contenido != "archivo_3.txt" {
+		t.Errorf("contenido %q, se esperaba %q", contenido, "archivo_3.txt")
+	}
+	assertConsistent(t, sb, path)
+}
+
+func TestCopyReleasesPartialCopy(t *testing.T) {
+	sb, path := newTestFilesystem(t, 12, 64)
+	createTestTree(t, sb, path)
+	inodos, bloques := sb.S_free_inodes_count, sb.S_free_blocks_count
+
+	// La copia necesita 6 inodos y solo quedan 2
+	err := sb.CopyPath(path, []string{"a"}, "sub", []string{"b"}, RootUID, RootUID)
+	if err == nil {
+		t.Fatal("se esperaba un error sin inodos suficientes para la copia")
+	}
+	if sb.S_free_inodes_count != inodos || sb.S_free_blocks_count != bloques {
+		t.Errorf("libres %d/%d, se esperaba %d/%d", sb.S_free_inodes_count, sb.S_free_blocks_count, inodos, bloques)
+	}
+	if _, err := sb.ResolvePath(path, []string{"b", "sub"}, RootUID, RootUID); err == nil {
+		t.Error("la copia incompleta quedo en la carpeta destino")
+	}
+	assertConsistent(t, sb, path)
+}
+
+func TestRenameRequiresParentWrite(t *testing.T) {
+	sb, path := newTestFilesystem(t, 32, 64)
+
+	usuario, grupo := int32(2), int32(3)
+	err := sb.CreateFolder(false, path, nil, "docs", RootUID, grupo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.CreateFile(false, path, []string{"docs"}, "privado.txt", "x", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.ChmodPath(path, []string{"docs", "privado.txt"}, [3]byte{'6', '0', '0'}, false, RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Con la carpeta 775 un usuario del grupo puede renombrar aunque no pueda escribir el archivo
+	err = sb.RenamePath(path, []string{"docs"}, "privado.txt", "renombrado.txt", usuario, grupo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustResolve(t, sb, path, "docs", "renombrado.txt")
+
+	// Sin permiso de escritura sobre la carpeta no se puede renombrar aunque el archivo sea suyo
+	err = sb.ChownPath(path, []string{"docs", "renombrado.txt"}, usuario, usuario, false, RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.RenamePath(path, []string{"docs"}, "renombrado.txt", "otro.txt", usuario, usuario)
+	if err == nil {
+		t.Fatal("se esperaba un error sin permiso de escritura sobre la carpeta")
+	}
+	mustResolve(t, sb, path, "docs", "renombrado.txt")
+	assertConsistent(t, sb, path)
+}
@@ -0,0 +1,93 @@
+package structures
+
+import (
+	"regexp"
+	"strings"
+)
+
+// FindPath busca desde la carpeta inicio los archivos y carpetas cuyo nombre coincide con el patrón
+// El patrón acepta ? (un caracter) y * (cero o mas caracteres), las carpetas sin permiso de lectura o de ejecución se omiten
+// Retorna las coincidencias en forma de árbol
+func (sb *SuperBlock) FindPath(path string, inicio []string, patron string, uid int32, gid int32) (string, error) {
+	inodeIndex, err := sb.ResolvePath(path, inicio, uid, gid)
+	if err != nil {
+		return "", err
+	}
+
+	re, err := patternToRegexp(patron)
+	if err != nil {
+		return "", err
+	}
+
+	lineas, err := sb.findInFolder(path, inodeIndex, re, 0, uid, gid)
+	if err != nil {
+		return "", err
+	}
+
+	// La primera linea es la carpeta desde donde se busco
+	raiz := "/" + strings.Join(inicio, "/")
+	if strings.Join(inicio, "") == "" {
+		raiz = "/"
+	}
+	return strings.Join(append([]string{raiz}, lineas...), "\n"), nil
+}
+
+// Convierte el patrón con ? y * en una expresión regular que debe de coincidir con todo el nombre
+func patternToRegexp(patron string) (*regexp.Regexp, error) {
+	var expresion strings.Builder
+	expresion.WriteString("(?i)^")
+	for _, caracter := range patron {
+		switch caracter {
+		case '?':
+			expresion.WriteString(".")
+		case '*':
+			expresion.WriteString(".*")
+		default:
+			expresion.WriteString(regexp.QuoteMeta(string(caracter)))
+		}
+	}
+	expresion.WriteString("$")
+	return regexp.Compile(expresion.String())
+}
+
+// Retorna las lineas del árbol de la carpeta, solo se incluyen las ramas que tienen coincidencias
+func (sb *SuperBlock) findInFolder(path string, inodeIndex int32, re *regexp.Regexp, nivel int, uid int32, gid int32) ([]string, error) {
+	folder := &Inode{}
+	err := folder.Deserialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		return nil, err
+	}
+	// Las carpetas que el usuario no puede leer o recorrer se omiten
+	if !folder.HasPermission(uid, gid, PermRead) || !folder.HasPermission(uid, gid, PermExec) {
+		return nil, nil
+	}
+
+	entradas, err := sb.GetFolderEntries(path, inodeIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	var lineas []string
+	sangria := strings.Repeat("|  ", nivel)
+	for _, content := range entradas {
+		inode := &Inode{}
+		err := inode.Deserialize(path, sb.InodeOffset(content.B_inodo))
+		if err != nil {
+			return nil, err
+		}
+
+		var hijas []string
+		if inode.I_type[0] == '0' {
+			hijas, err = sb.findInFolder(path, content.B_inodo, re, nivel+1, uid, gid)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if re.MatchString(content.GetName()) || len(hijas) > 0 {
+			lineas = append(lineas, sangria+"|_ "+content.GetName())
+			lineas = append(lineas, hijas...)
+		}
+	}
+	return lineas, nil
+}
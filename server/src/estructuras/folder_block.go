@@ -4,13 +4,13 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 )
 
 type FolderBlock struct {
-	B_content [4]FolderContent // 4 * 16 = 64 bytes
-	// Total: 64 bytes
+	B_content []FolderContent // S_block_size / 16 entradas
 }
 
 type FolderContent struct {
@@ -19,6 +19,27 @@ type FolderContent struct {
 	// Total: 16 bytes
 }
 
+// Los nombres de mas de 12 bytes siguen en las entradas siguientes del mismo bloque,
+// esas entradas de continuación tienen este valor en B_inodo
+const nameContinuation int32 = -2
+
+// FolderEntry es una entrada de carpeta con el nombre completo, puede ocupar varias FolderContent
+type FolderEntry struct {
+	Name    string
+	B_inodo int32
+	index   int // Posición de la primera FolderContent en el bloque
+	count   int // Cantidad de FolderContent que ocupa
+}
+
+// NewFolderBlock crea un bloque de carpeta del tamaño de bloque indicado con todas sus entradas libres
+func NewFolderBlock(blockSize int32) *FolderBlock {
+	fb := &FolderBlock{B_content: make([]FolderContent, blockSize/int32(binary.Size(FolderContent{})))}
+	for i := range fb.B_content {
+		fb.B_content[i] = FolderContent{B_name: [12]byte{'-'}, B_inodo: -1}
+	}
+	return fb
+}
+
 // Serialize escribe la estructura FolderBlock en un archivo binario en la posición especificada
 func (fb *FolderBlock) Serialize(path string, offset int64) error {
 	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0644)
@@ -33,8 +54,8 @@ func (fb *FolderBlock) Serialize(path string, offset int64) error {
 		return err
 	}
 
-	// Serializar la estructura FolderBlock directamente en el archivo
-	err = binary.Write(file, binary.LittleEndian, fb)
+	// Serializar las entradas del bloque directamente en el archivo
+	err = binary.Write(file, binary.LittleEndian, fb.B_content)
 	if err != nil {
 		return err
 	}
@@ -56,22 +77,22 @@ func (fb *FolderBlock) Deserialize(path string, offset int64) error {
 		return err
 	}
 
-	// Obtener el tamaño de la estructura FolderBlock
-	fbSize := binary.Size(fb)
+	// Obtener el tamaño del bloque, las entradas se crean con NewFolderBlock
+	fbSize := binary.Size(fb.B_content)
 	if fbSize <= 0 {
 		return fmt.Errorf("invalid FolderBlock size: %d", fbSize)
 	}
 
 	// Leer solo la cantidad de bytes que corresponden al tamaño de la estructura FolderBlock
 	buffer := make([]byte, fbSize)
-	_, err = file.Read(buffer)
+	_, err = io.ReadFull(file, buffer)
 	if err != nil {
 		return err
 	}
 
 	// Deserializar los bytes leídos en la estructura FolderBlock
 	reader := bytes.NewReader(buffer)
-	err = binary.Read(reader, binary.LittleEndian, fb)
+	err = binary.Read(reader, binary.LittleEndian, fb.B_content)
 	if err != nil {
 		return err
 	}
@@ -102,3 +123,86 @@ func (fb *FolderBlock) ObtenerDot() string {
 	}
 	return cadena
 }
+
+// GetName retorna el nombre de la entrada sin los caracteres nulos
+func (content FolderContent) GetName() string {
+	return strings.Trim(string(content.B_name[:]), "\x00 ")
+}
+
+// GetName retorna el nombre completo de la entrada
+func (entry FolderEntry) GetName() string {
+	return entry.Name
+}
+
+// Cantidad de FolderContent que necesita el nombre
+func nameEntryCount(name string) int {
+	tamano := len(FolderContent{}.B_name)
+	return max(1, (len(name)+tamano-1)/tamano)
+}
+
+// Retorna la cantidad de entradas reservadas al inicio del bloque
+// Solo el primer bloque de una carpeta tiene . y .., los discos anteriores las tienen en todos los bloques
+func (fb *FolderBlock) reservedEntries() int {
+	if len(fb.B_content) >= 2 && fb.B_content[0].GetName() == "." && fb.B_content[1].GetName() == ".." {
+		return 2
+	}
+	return 0
+}
+
+// Entries retorna las entradas del bloque sin contar . y .., uniendo los nombres largos
+// Las entradas de continuación sin una entrada inicial se ignoran
+func (fb *FolderBlock) Entries() []FolderEntry {
+	var entradas []FolderEntry
+	for i := fb.reservedEntries(); i < len(fb.B_content); i++ {
+		content := fb.B_content[i]
+		if content.B_inodo == -1 || content.B_inodo == nameContinuation {
+			continue
+		}
+
+		nombre := strings.TrimRight(string(content.B_name[:]), "\x00")
+		count := 1
+		for i+count < len(fb.B_content) && fb.B_content[i+count].B_inodo == nameContinuation {
+			nombre += strings.TrimRight(string(fb.B_content[i+count].B_name[:]), "\x00")
+			count++
+		}
+		entradas = append(entradas, FolderEntry{Name: strings.Trim(nombre, "\x00 "), B_inodo: content.B_inodo, index: i, count: count})
+		i += count - 1
+	}
+	return entradas
+}
+
+// Retorna la posición de count entradas libres seguidas, -1 si no hay espacio en el bloque
+func (fb *FolderBlock) freeEntries(count int) int {
+	seguidas := 0
+	for i := fb.reservedEntries(); i < len(fb.B_content); i++ {
+		if fb.B_content[i].B_inodo != -1 {
+			seguidas = 0
+			continue
+		}
+		seguidas++
+		if seguidas == count {
+			return i - count + 1
+		}
+	}
+	return -1
+}
+
+// Escribe el nombre desde la posición index, repartido en partes de 12 bytes
+func (fb *FolderBlock) setEntry(index int, name string, inodeIndex int32) {
+	tamano := len(FolderContent{}.B_name)
+	for i := 0; i < nameEntryCount(name); i++ {
+		content := FolderContent{B_inodo: inodeIndex}
+		if i > 0 {
+			content.B_inodo = nameContinuation
+		}
+		copy(content.B_name[:], name[i*tamano:])
+		fb.B_content[index+i] = content
+	}
+}
+
+// Deja libres todas las FolderContent que ocupa la entrada
+func (fb *FolderBlock) clearEntry(entry FolderEntry) {
+	for i := entry.index; i < entry.index+entry.count; i++ {
+		fb.B_content[i] = FolderContent{B_name: [12]byte{'-'}, B_inodo: -1}
+	}
+}
@@ -0,0 +1,118 @@
+package structures
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEntriesJoinLongNames(t *testing.T) {
+	sb := &SuperBlock{S_block_size: 64}
+	block := sb.newFolderBlock(3, 0)
+
+	nombre := "documentos_2025"
+	block.setEntry(2, nombre, 7)
+	entradas := block.Entries()
+	if len(entradas) != 1 || entradas[0].GetName() != nombre || entradas[0].B_inodo != 7 || entradas[0].count != 2 {
+		t.Fatalf("entradas %+v, se esperaba %s en dos partes", entradas, nombre)
+	}
+	if block.B_content[3].B_inodo != nameContinuation {
+		t.Errorf("la segunda parte tiene B_inodo %d, se esperaba %d", block.B_content[3].B_inodo, nameContinuation)
+	}
+	if libre := block.freeEntries(2); libre != -1 {
+		t.Errorf("el bloque no tiene dos entradas libres, se obtuvo la posición %d", libre)
+	}
+
+	block.clearEntry(entradas[0])
+	if len(block.Entries()) != 0 || block.freeEntries(2) != 2 {
+		t.Errorf("las dos partes del nombre deben de quedar libres")
+	}
+}
+
+func TestEntriesSkipDotsOnlyWhenPresent(t *testing.T) {
+	// Solo el primer bloque tiene . y .., los siguientes usan todas sus entradas
+	block := NewFolderBlock(64)
+	block.setEntry(0, "a", 4)
+	block.setEntry(1, "b", 5)
+	if entradas := block.Entries(); len(entradas) != 2 {
+		t.Errorf("entradas %+v, se esperaba a y b", entradas)
+	}
+
+	// Los discos anteriores tienen . y .. en todos los bloques
+	sb := &SuperBlock{S_block_size: 64}
+	anterior := sb.newFolderBlock(3, 0)
+	anterior.setEntry(2, "c", 6)
+	if entradas := anterior.Entries(); len(entradas) != 1 || entradas[0].GetName() != "c" {
+		t.Errorf("entradas %+v, se esperaba solo c", entradas)
+	}
+	if libre := anterior.freeEntries(1); libre != 3 {
+		t.Errorf("primera entrada libre %d, se esperaba 3", libre)
+	}
+}
+
+func TestLongNamesInFolder(t *testing.T) {
+	sb, path := newTestFilesystem(t, 32, 64)
+
+	// Un nombre del largo máximo ocupa un bloque completo
+	if sb.MaxNameLength() != 48 {
+		t.Fatalf("largo máximo %d, se esperaba 48", sb.MaxNameLength())
+	}
+	err := sb.CreateFolder(false, path, nil, "docs", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var nombres []string
+	for i := 0; i < 3; i++ {
+		nombre := fmt.Sprintf("%d%s", i, strings.Repeat("n", sb.MaxNameLength()-1))
+		err := sb.CreateFile(false, path, []string{"docs"}, nombre, "", RootUID, RootUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nombres = append(nombres, nombre)
+	}
+	// Nombres que solo se diferencian despues de los primeros 12 bytes
+	for _, nombre := range []string{"documentos_2025", "documentos_2"} {
+		err := sb.CreateFile(false, path, []string{"docs"}, nombre, nombre, RootUID, RootUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nombres = append(nombres, nombre)
+	}
+
+	docs, err := sb.ResolvePath(path, []string{"docs"}, RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entradas, err := sb.GetFolderEntries(path, docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entradas) != len(nombres) {
+		t.Fatalf("la carpeta tiene %d entradas, se esperaba %d", len(entradas), len(nombres))
+	}
+	for _, nombre := range nombres {
+		inodeIndex, err := sb.lookupEntry(path, docs, nombre, RootUID, RootUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if inodeIndex == -1 {
+			t.Errorf("no se encontro %s", nombre)
+		}
+	}
+	contenido, err := sb.GetFileContent(path, []string{"docs"}, "documentos_2", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contenido != "documentos_2" {
+		t.Errorf("contenido %q, se esperaba %q", contenido, "documentos_2")
+	}
+	assertConsistent(t, sb, path)
+
+	// Los nombres que no caben se rechazan, nunca se truncan
+	for _, nombre := range []string{strings.Repeat("x", sb.MaxNameLength()+1), ".", ".."} {
+		err := sb.CreateFile(false, path, []string{"docs"}, nombre, "", RootUID, RootUID)
+		if err == nil {
+			t.Errorf("se esperaba un error al crear %q", nombre)
+		}
+	}
+}
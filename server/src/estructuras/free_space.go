@@ -0,0 +1,100 @@
+package structures
+
+import (
+	"encoding/binary"
+	"sort"
+)
+
+// FreeSpace representa un espacio libre dentro del disco o de la particion extendida
+type FreeSpace struct {
+	Start int32 // Byte donde inicia el espacio libre
+	Size  int32 // Tamaño del espacio libre en bytes
+}
+
+// Espacio ocupado dentro del disco, se usa para calcular los espacios libres
+type usedSpace struct {
+	start int32
+	end   int32
+}
+
+// Calcula los espacios libres entre inicio y fin dejando fuera los espacios ocupados
+func buildFreeSpaces(inicio int32, fin int32, ocupados []usedSpace) []FreeSpace {
+	// Ordenar los espacios ocupados por su inicio
+	sort.Slice(ocupados, func(i, j int) bool {
+		return ocupados[i].start < ocupados[j].start
+	})
+
+	var libres []FreeSpace
+	actual := inicio
+	for _, ocupado := range ocupados {
+		if ocupado.start > actual {
+			libres = append(libres, FreeSpace{Start: actual, Size: ocupado.start - actual})
+		}
+		if ocupado.end > actual {
+			actual = ocupado.end
+		}
+	}
+	if fin > actual {
+		libres = append(libres, FreeSpace{Start: actual, Size: fin - actual})
+	}
+	return libres
+}
+
+// Selecciona el espacio libre donde cabe size según el ajuste (F: primer, B: mejor, W: peor)
+// Retorna el byte de inicio o -1 si no hay ningun espacio suficiente
+func SelectFreeSpace(libres []FreeSpace, size int32, fit byte) int32 {
+	elegido := -1
+	for i, libre := range libres {
+		if libre.Size < size {
+			continue
+		}
+		switch fit {
+		case 'B':
+			// Mejor ajuste: el espacio más pequeño donde quepa
+			if elegido == -1 || libre.Size < libres[elegido].Size {
+				elegido = i
+			}
+		case 'W':
+			// Peor ajuste: el espacio más grande
+			if elegido == -1 || libre.Size > libres[elegido].Size {
+				elegido = i
+			}
+		default:
+			// Primer ajuste: el primer espacio donde quepa
+			if elegido == -1 {
+				elegido = i
+			}
+		}
+	}
+	if elegido == -1 {
+		return -1
+	}
+	return libres[elegido].Start
+}
+
+// Obtiene los espacios libres del disco, incluyendo los huecos que dejan las particiones eliminadas
+func (mbr *MBR) GetFreeSpaces() []FreeSpace {
+	var ocupados []usedSpace
+	for _, partition := range mbr.Mbr_partitions {
+		if partition.Part_start == -1 || partition.Part_size <= 0 {
+			continue
+		}
+		ocupados = append(ocupados, usedSpace{start: partition.Part_start, end: partition.Part_start + partition.Part_size})
+	}
+	return buildFreeSpaces(int32(binary.Size(mbr)), mbr.Mbr_size, ocupados)
+}
+
+// Obtiene los espacios libres dentro de la particion extendida a partir de su cadena de EBRs
+// Cada espacio libre incluye los bytes del EBR que necesita la nueva particion logica
+func GetLogicalFreeSpaces(extendida *PARTITION, cadena []EBR) []FreeSpace {
+	var ocupados []usedSpace
+	for _, ebr := range cadena {
+		// Los EBR vacios no ocupan espacio, el primero se reutiliza al crear una logica en el inicio
+		if ebr.IsEmpty() {
+			continue
+		}
+		fin := ebr.Ebr_start + int32(binary.Size(ebr)) + ebr.Ebr_size
+		ocupados = append(ocupados, usedSpace{start: ebr.Ebr_start, end: fin})
+	}
+	return buildFreeSpaces(extendida.Part_start, extendida.Part_start+extendida.Part_size, ocupados)
+}
@@ -0,0 +1,52 @@
+package structures
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestSelectFreeSpace(t *testing.T) {
+	// Espacios de 20, 10 y 60 bytes
+	libres := []FreeSpace{{Start: 100, Size: 20}, {Start: 200, Size: 10}, {Start: 300, Size: 60}}
+	pruebas := []struct {
+		fit  byte
+		size int32
+		want int32
+	}{
+		{'F', 8, 100},
+		{'B', 8, 200},
+		{'W', 8, 300},
+		{'B', 15, 100},
+		{'F', 30, 300},
+		{'F', 10, 100},
+		{'B', 10, 200},
+		{'W', 61, -1},
+	}
+	for _, prueba := range pruebas {
+		if got := SelectFreeSpace(libres, prueba.size, prueba.fit); got != prueba.want {
+			t.Errorf("ajuste %c con %d bytes inicia en %d, se esperaba %d", prueba.fit, prueba.size, got, prueba.want)
+		}
+	}
+}
+
+func TestGetFreeSpacesIncludesHoles(t *testing.T) {
+	inicio := int32(binary.Size(MBR{}))
+	mbr := &MBR{Mbr_size: inicio + 100}
+	for i := range mbr.Mbr_partitions {
+		mbr.Mbr_partitions[i].ResetPartition()
+	}
+	// Particiones en [inicio+10, inicio+30) y [inicio+50, inicio+60)
+	mbr.Mbr_partitions[0].CreatePartition(int(inicio+50), 10, "P", "FF", "b", 2)
+	mbr.Mbr_partitions[2].CreatePartition(int(inicio+10), 20, "P", "FF", "a", 1)
+
+	want := []FreeSpace{{Start: inicio, Size: 10}, {Start: inicio + 30, Size: 20}, {Start: inicio + 60, Size: 40}}
+	got := mbr.GetFreeSpaces()
+	if len(got) != len(want) {
+		t.Fatalf("espacios libres %+v, se esperaba %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("espacio %d es %+v, se esperaba %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,368 @@
+package structures
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Códigos de los problemas que puede encontrar fsck
+const (
+	IssueOrphanInode     = "INODO_HUERFANO"         // Inodo marcado como usado que no esta en ninguna carpeta
+	IssueFreeInodeInUse  = "INODO_LIBRE_EN_USO"     // Inodo de una carpeta marcado como libre
+	IssueUnusedBlock     = "BLOQUE_NO_REFERENCIADO" // Bloque marcado como usado que ningun inodo utiliza
+	IssueFreeBlockInUse  = "BLOQUE_LIBRE_EN_USO"    // Bloque de un inodo marcado como libre
+	IssueDuplicateBlock  = "BLOQUE_DUPLICADO"       // Bloque utilizado por más de un inodo
+	IssueInvalidPointer  = "APUNTADOR_INVALIDO"     // Apuntador a un inodo o bloque fuera de rango
+	IssueDotEntries      = "ENTRADAS_PUNTO"         // Las entradas . o .. no apuntan a la carpeta o a su padre
+	IssueSizeMismatch    = "TAMANO_INODO"           // I_size no coincide con el contenido del archivo
+	IssueInvalidType     = "TIPO_INVALIDO"          // El inodo no es carpeta, archivo ni enlace simbolico
+	IssueLinkCount       = "ENLACES"                // I_links no coincide con las entradas que apuntan al inodo
+	IssueCounterMismatch = "CONTADOR"               // Los contadores del superbloque no coinciden con el contenido
+)
+
+// Estado del recorrido de fsck por el arbol de carpetas
+type fsCheck struct {
+	path        string
+	sb          *SuperBlock
+	totalInodes int32
+	totalBlocks int32
+	inodos      map[int32]bool   // Inodos alcanzables desde la raíz
+	enlaces     map[int32]int32  // Cantidad de entradas que apuntan a cada inodo
+	bloques     map[int32]string // Bloques referenciados y la ruta del inodo que los usa
+	issues      []DiskIssue
+}
+
+// CheckFilesystem valida el superbloque, los bitmaps, la tabla de inodos y el arbol de carpetas
+// Si repair es true se reparan los bitmaps y los contadores, el superbloque lo serializa quien llama
+func (sb *SuperBlock) CheckFilesystem(path string, repair bool) ([]DiskIssue, error) {
+	// Los totales se obtienen de la distribución de la partición y no de los contadores
+	check := &fsCheck{
+		path:        path,
+		sb:          sb,
+		totalInodes: sb.S_bm_block_start - sb.S_bm_inode_start,
+		totalBlocks: sb.S_inode_start - sb.S_bm_block_start,
+		inodos:      make(map[int32]bool),
+		enlaces:     make(map[int32]int32),
+		bloques:     make(map[int32]string),
+	}
+
+	// Recorrer el arbol desde la raíz, la raíz es su propio padre
+	err := check.checkInode(0, 0, "/")
+	if err != nil {
+		return nil, err
+	}
+
+	err = check.checkLinks()
+	if err != nil {
+		return nil, err
+	}
+
+	err = check.checkBitmaps()
+	if err != nil {
+		return nil, err
+	}
+	check.checkCounters()
+
+	if repair {
+		for i := range check.issues {
+			if !check.issues[i].Repairable {
+				continue
+			}
+			err := check.issues[i].repair()
+			if err != nil {
+				return check.issues, fmt.Errorf("error reparando %s: %w", check.issues[i].Code, err)
+			}
+			check.issues[i].Repaired = true
+		}
+	}
+
+	return check.issues, nil
+}
+
+func (check *fsCheck) add(issue DiskIssue) {
+	check.issues = append(check.issues, issue)
+}
+
+// Valida el inodo y, si es una carpeta, todo su contenido
+func (check *fsCheck) checkInode(inodeIndex int32, parentIndex int32, ruta string) error {
+	// Los enlaces duros hacen que un inodo se encuentre varias veces, solo se valida la primera
+	check.enlaces[inodeIndex]++
+	if check.inodos[inodeIndex] {
+		return nil
+	}
+	check.inodos[inodeIndex] = true
+
+	inode := &Inode{}
+	err := inode.Deserialize(check.path, check.sb.InodeOffset(inodeIndex))
+	if err != nil {
+		return err
+	}
+	if inode.I_type[0] != '0' && inode.I_type[0] != '1' && inode.I_type[0] != '2' {
+		check.add(DiskIssue{
+			Code:    IssueInvalidType,
+			Message: fmt.Sprintf("el inodo %d (%s) tiene el tipo %q", inodeIndex, ruta, inode.I_type[0]),
+		})
+		return nil
+	}
+
+	datos, err := check.collectBlocks(inode, ruta)
+	if err != nil {
+		return err
+	}
+
+	// Los enlaces simbolicos guardan la ruta destino igual que el contenido de un archivo
+	if inode.I_type[0] != '0' {
+		return check.checkFileSize(inodeIndex, inode, datos, ruta)
+	}
+
+	for i, blockIndex := range datos {
+		block := NewFolderBlock(check.sb.S_block_size)
+		err := block.Deserialize(check.path, check.sb.BlockOffset(blockIndex))
+		if err != nil {
+			return err
+		}
+
+		// El primer bloque debe de tener . y .., los siguientes solo se validan si los tienen (discos anteriores)
+		punto, puntoPunto := block.B_content[0], block.B_content[1]
+		puntoValido := punto.GetName() == "." && punto.B_inodo == inodeIndex && puntoPunto.GetName() == ".." && puntoPunto.B_inodo == parentIndex
+		if (i == 0 || block.reservedEntries() > 0) && !puntoValido {
+			check.add(DiskIssue{
+				Code:    IssueDotEntries,
+				Message: fmt.Sprintf("el bloque %d de %s tiene %s=%d y %s=%d, se esperaba .=%d y ..=%d", blockIndex, ruta, punto.GetName(), punto.B_inodo, puntoPunto.GetName(), puntoPunto.B_inodo, inodeIndex, parentIndex),
+			})
+		}
+
+		for _, content := range block.Entries() {
+			hijo := strings.TrimSuffix(ruta, "/") + "/" + content.GetName()
+			if content.B_inodo < 0 || content.B_inodo >= check.totalInodes {
+				check.add(DiskIssue{
+					Code:    IssueInvalidPointer,
+					Message: fmt.Sprintf("la entrada %s apunta al inodo %d fuera de rango", hijo, content.B_inodo),
+				})
+				continue
+			}
+			err := check.checkInode(content.B_inodo, inodeIndex, hijo)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Registra los bloques de datos y de apuntadores del inodo y retorna los de datos en orden
+func (check *fsCheck) collectBlocks(inode *Inode, ruta string) ([]int32, error) {
+	var datos []int32
+	for i, blockIndex := range inode.I_block {
+		if blockIndex == -1 {
+			continue
+		}
+		nivel := 0
+		if i >= directBlocks {
+			nivel = i - directBlocks + 1
+		}
+		err := check.collectPointer(blockIndex, nivel, ruta, &datos)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return datos, nil
+}
+
+// Registra el bloque y, si es de apuntadores, sus hijos
+func (check *fsCheck) collectPointer(blockIndex int32, nivel int, ruta string, datos *[]int32) error {
+	if blockIndex < 0 || blockIndex >= check.totalBlocks {
+		check.add(DiskIssue{
+			Code:    IssueInvalidPointer,
+			Message: fmt.Sprintf("%s apunta al bloque %d fuera de rango", ruta, blockIndex),
+		})
+		return nil
+	}
+	if anterior, ok := check.bloques[blockIndex]; ok {
+		check.add(DiskIssue{
+			Code:    IssueDuplicateBlock,
+			Message: fmt.Sprintf("el bloque %d lo usan %s y %s", blockIndex, anterior, ruta),
+		})
+		return nil
+	}
+	check.bloques[blockIndex] = ruta
+
+	if nivel == 0 {
+		*datos = append(*datos, blockIndex)
+		return nil
+	}
+
+	pointerBlock := NewPointerBlock(check.sb.S_block_size)
+	err := pointerBlock.Deserialize(check.path, check.sb.BlockOffset(blockIndex))
+	if err != nil {
+		return err
+	}
+	for _, hijo := range pointerBlock.P_pointers {
+		if hijo == -1 {
+			continue
+		}
+		err := check.collectPointer(hijo, nivel-1, ruta, datos)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// El tamaño del archivo debe de ser igual a la cantidad de bytes de sus bloques
+func (check *fsCheck) checkFileSize(inodeIndex int32, inode *Inode, datos []int32, ruta string) error {
+	tamano := 0
+	for _, blockIndex := range datos {
+		fileBlock := NewFileBlock(check.sb.S_block_size)
+		err := fileBlock.Deserialize(check.path, check.sb.BlockOffset(blockIndex))
+		if err != nil {
+			return err
+		}
+		tamano += len(strings.TrimRight(string(fileBlock.B_content[:]), "\x00"))
+	}
+	if int(inode.I_size) != tamano {
+		check.add(DiskIssue{
+			Code:    IssueSizeMismatch,
+			Message: fmt.Sprintf("el inodo %d (%s) indica %d bytes pero sus bloques tienen %d", inodeIndex, ruta, inode.I_size, tamano),
+		})
+	}
+	return nil
+}
+
+// Compara I_links de cada inodo alcanzable con las entradas que se encontraron en el arbol
+func (check *fsCheck) checkLinks() error {
+	// Se recorren en orden para que el reporte siempre sea el mismo
+	for _, inodeIndex := range slices.Sorted(maps.Keys(check.enlaces)) {
+		cantidad := check.enlaces[inodeIndex]
+		inode := &Inode{}
+		err := inode.Deserialize(check.path, check.sb.InodeOffset(inodeIndex))
+		if err != nil {
+			return err
+		}
+		if inode.I_links == cantidad {
+			continue
+		}
+		check.add(DiskIssue{
+			Code:       IssueLinkCount,
+			Message:    fmt.Sprintf("el inodo %d indica %d enlaces pero %d entradas apuntan a el", inodeIndex, inode.I_links, cantidad),
+			Repairable: true,
+			repair: func() error {
+				inode.I_links = cantidad
+				return inode.Serialize(check.path, check.sb.InodeOffset(inodeIndex))
+			},
+		})
+	}
+	return nil
+}
+
+// Compara los bitmaps con los inodos y bloques que se encontraron en el arbol
+func (check *fsCheck) checkBitmaps() error {
+	path, sb := check.path, check.sb
+
+	bitmapInodos, err := readBitmap(path, sb.S_bm_inode_start, check.totalInodes)
+	if err != nil {
+		return err
+	}
+	for i, b := range bitmapInodos {
+		index := int32(i)
+		usado := check.inodos[index]
+		if b == '1' && !usado {
+			check.add(DiskIssue{
+				Code:       IssueOrphanInode,
+				Message:    fmt.Sprintf("el inodo %d esta marcado como usado pero no esta en ninguna carpeta", index),
+				Repairable: true,
+				repair: func() error {
+					return writeBitmap(path, sb.S_bm_inode_start, index, '0')
+				},
+			})
+		} else if b != '1' && usado {
+			check.add(DiskIssue{
+				Code:       IssueFreeInodeInUse,
+				Message:    fmt.Sprintf("el inodo %d esta en uso pero el bitmap lo marca libre", index),
+				Repairable: true,
+				repair: func() error {
+					return writeBitmap(path, sb.S_bm_inode_start, index, '1')
+				},
+			})
+		}
+	}
+
+	bitmapBloques, err := readBitmap(path, sb.S_bm_block_start, check.totalBlocks)
+	if err != nil {
+		return err
+	}
+	for i, b := range bitmapBloques {
+		index := int32(i)
+		ruta, usado := check.bloques[index]
+		if b == '1' && !usado {
+			check.add(DiskIssue{
+				Code:       IssueUnusedBlock,
+				Message:    fmt.Sprintf("el bloque %d esta marcado como usado pero ningun inodo lo utiliza", index),
+				Repairable: true,
+				repair: func() error {
+					return writeBitmap(path, sb.S_bm_block_start, index, '0')
+				},
+			})
+		} else if b != '1' && usado {
+			check.add(DiskIssue{
+				Code:       IssueFreeBlockInUse,
+				Message:    fmt.Sprintf("el bloque %d lo usa %s pero el bitmap lo marca libre", index, ruta),
+				Repairable: true,
+				repair: func() error {
+					return writeBitmap(path, sb.S_bm_block_start, index, '1')
+				},
+			})
+		}
+	}
+	return nil
+}
+
+// Los contadores del superbloque deben de coincidir con los inodos y bloques en uso
+// Se revisa al final para que su reparación se aplique despues de la de los bitmaps
+func (check *fsCheck) checkCounters() {
+	sb := check.sb
+	inodosUsados := int32(len(check.inodos))
+	bloquesUsados := int32(len(check.bloques))
+
+	if sb.S_inodes_count == inodosUsados && sb.S_free_inodes_count == check.totalInodes-inodosUsados &&
+		sb.S_blocks_count == bloquesUsados && sb.S_free_blocks_count == check.totalBlocks-bloquesUsados {
+		return
+	}
+
+	check.add(DiskIssue{
+		Code: IssueCounterMismatch,
+		Message: fmt.Sprintf("el superbloque indica %d/%d inodos y %d/%d bloques (usados/libres), se encontraron %d/%d y %d/%d",
+			sb.S_inodes_count, sb.S_free_inodes_count, sb.S_blocks_count, sb.S_free_blocks_count,
+			inodosUsados, check.totalInodes-inodosUsados, bloquesUsados, check.totalBlocks-bloquesUsados),
+		Repairable: true,
+		repair: func() error {
+			sb.S_inodes_count = inodosUsados
+			sb.S_free_inodes_count = check.totalInodes - inodosUsados
+			sb.S_blocks_count = bloquesUsados
+			sb.S_free_blocks_count = check.totalBlocks - bloquesUsados
+
+			// Los primeros libres se vuelven a calcular con los bitmaps ya reparados
+			bitmapInodos, err := readBitmap(check.path, sb.S_bm_inode_start, check.totalInodes)
+			if err != nil {
+				return err
+			}
+			bitmapBloques, err := readBitmap(check.path, sb.S_bm_block_start, check.totalBlocks)
+			if err != nil {
+				return err
+			}
+			siguiente := firstFree(bitmapInodos, 0)
+			if siguiente == -1 {
+				siguiente = check.totalInodes
+			}
+			sb.S_first_ino = sb.S_inode_start + siguiente*sb.S_inode_size
+			siguiente = firstFree(bitmapBloques, 0)
+			if siguiente == -1 {
+				siguiente = check.totalBlocks
+			}
+			sb.S_first_blo = sb.S_block_start + siguiente*sb.S_block_size
+			return nil
+		},
+	})
+}
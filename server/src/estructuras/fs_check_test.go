@@ -0,0 +1,94 @@
+package structures
+
+import "testing"
+
+// Retorna los códigos de los problemas encontrados
+func issueCodes(issues []DiskIssue) map[string]int {
+	codigos := make(map[string]int)
+	for _, issue := range issues {
+		codigos[issue.Code]++
+	}
+	return codigos
+}
+
+func TestCheckFilesystemRepairsBitmaps(t *testing.T) {
+	sb, path := newTestFilesystem(t, 16, 64)
+
+	err := sb.CreateFile(false, path, nil, "a.txt", "contenido", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archivo := mustResolve(t, sb, path, "a.txt")
+	inode := &Inode{}
+	err = inode.Deserialize(path, sb.InodeOffset(archivo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertConsistent(t, sb, path)
+
+	// Se libera en el bitmap un bloque en uso y se marcan usados un inodo y un bloque libres
+	err = writeBitmap(path, sb.S_bm_block_start, inode.I_block[0], '0')
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = writeBitmap(path, sb.S_bm_inode_start, 10, '1')
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = writeBitmap(path, sb.S_bm_block_start, 20, '1')
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := sb.CheckFilesystem(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codigos := issueCodes(issues)
+	for _, codigo := range []string{IssueFreeBlockInUse, IssueOrphanInode, IssueUnusedBlock} {
+		if codigos[codigo] != 1 {
+			t.Errorf("se encontraron %d problemas %s, se esperaba 1", codigos[codigo], codigo)
+		}
+	}
+	if len(issues) != 3 {
+		t.Errorf("se encontraron %d problemas, se esperaban 3: %v", len(issues), codigos)
+	}
+
+	issues, err = sb.CheckFilesystem(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range issues {
+		if !issue.Repaired {
+			t.Errorf("[%s] no se reparo: %s", issue.Code, issue.Message)
+		}
+	}
+	assertConsistent(t, sb, path)
+
+	// El bloque del archivo ya no se vuelve a asignar
+	bloque, err := sb.AllocateBlock(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bloque == inode.I_block[0] {
+		t.Errorf("se asigno el bloque %d que usa a.txt", bloque)
+	}
+}
+
+func TestCheckFilesystemRepairsCounters(t *testing.T) {
+	sb, path := newTestFilesystem(t, 16, 64)
+
+	sb.S_free_blocks_count += 5
+	sb.S_inodes_count--
+	issues, err := sb.CheckFilesystem(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if codigos := issueCodes(issues); len(issues) != 1 || codigos[IssueCounterMismatch] != 1 {
+		t.Fatalf("problemas %v, se esperaba solo %s", codigos, IssueCounterMismatch)
+	}
+	if sb.S_free_blocks_count != sb.TotalBlocks()-2 || sb.S_inodes_count != 2 {
+		t.Errorf("contadores %d inodos y %d bloques libres, se esperaba 2 y %d", sb.S_inodes_count, sb.S_free_blocks_count, sb.TotalBlocks()-2)
+	}
+	assertConsistent(t, sb, path)
+}
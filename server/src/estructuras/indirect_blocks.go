@@ -0,0 +1,249 @@
+package structures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// I_block[0..11] son apuntadores directos, I_block[12] es el simple indirecto,
+// I_block[13] el doble indirecto e I_block[14] el triple indirecto
+const directBlocks = 12
+
+// Cantidad de apuntadores que caben en un bloque de apuntadores
+func (sb *SuperBlock) pointersPerBlock() int {
+	return int(sb.S_block_size) / 4
+}
+
+// Cantidad de bloques de datos que alcanza un apuntador del nivel indicado (0 = directo)
+func (sb *SuperBlock) blocksPerLevel(level int) int {
+	total := 1
+	for i := 0; i < level; i++ {
+		total *= sb.pointersPerBlock()
+	}
+	return total
+}
+
+// MaxFileBlocks retorna la cantidad máxima de bloques de datos que puede tener un inodo
+func (sb *SuperBlock) MaxFileBlocks() int {
+	return directBlocks + sb.blocksPerLevel(1) + sb.blocksPerLevel(2) + sb.blocksPerLevel(3)
+}
+
+// GetInodeBlocks retorna en orden los bloques de datos del inodo, incluyendo los de los bloques indirectos
+func (sb *SuperBlock) GetInodeBlocks(path string, inode *Inode) ([]int32, error) {
+	datos, _, err := sb.walkInodeBlocks(path, inode)
+	return datos, err
+}
+
+// GetInodePointerBlocks retorna los bloques de apuntadores que usa el inodo
+func (sb *SuperBlock) GetInodePointerBlocks(path string, inode *Inode) ([]int32, error) {
+	_, apuntadores, err := sb.walkInodeBlocks(path, inode)
+	return apuntadores, err
+}
+
+// Recorre los apuntadores directos e indirectos del inodo
+func (sb *SuperBlock) walkInodeBlocks(path string, inode *Inode) ([]int32, []int32, error) {
+	var datos, apuntadores []int32
+	for i, blockIndex := range inode.I_block {
+		if blockIndex == -1 {
+			continue
+		}
+		nivel := 0
+		if i >= directBlocks {
+			nivel = i - directBlocks + 1
+		}
+		err := sb.walkPointer(path, blockIndex, nivel, &datos, &apuntadores)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return datos, apuntadores, nil
+}
+
+// Agrega el bloque a la lista de datos o, si es un bloque de apuntadores, recorre sus hijos
+func (sb *SuperBlock) walkPointer(path string, blockIndex int32, nivel int, datos *[]int32, apuntadores *[]int32) error {
+	if nivel == 0 {
+		*datos = append(*datos, blockIndex)
+		return nil
+	}
+
+	*apuntadores = append(*apuntadores, blockIndex)
+	pointerBlock := NewPointerBlock(sb.S_block_size)
+	err := pointerBlock.Deserialize(path, sb.BlockOffset(blockIndex))
+	if err != nil {
+		return err
+	}
+	for _, hijo := range pointerBlock.P_pointers {
+		if hijo == -1 {
+			continue
+		}
+		err := sb.walkPointer(path, hijo, nivel-1, datos, apuntadores)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reserva un bloque de apuntadores con todos sus apuntadores en -1
+func (sb *SuperBlock) newPointerBlock(path string) (int32, error) {
+	blockIndex, err := sb.AllocateBlock(path)
+	if err != nil {
+		return -1, err
+	}
+	pointerBlock := NewPointerBlock(sb.S_block_size)
+	err = pointerBlock.Serialize(path, sb.BlockOffset(blockIndex))
+	if err != nil {
+		return -1, err
+	}
+	return blockIndex, nil
+}
+
+// SetInodeBlock asigna blockIndex como el bloque de datos número n del inodo
+// Si hace falta se crean los bloques de apuntadores intermedios, el inodo no se serializa
+func (sb *SuperBlock) SetInodeBlock(path string, inode *Inode, n int, blockIndex int32) error {
+	if n < directBlocks {
+		inode.I_block[n] = blockIndex
+		return nil
+	}
+
+	n -= directBlocks
+	for nivel := 1; nivel <= 3; nivel++ {
+		capacidad := sb.blocksPerLevel(nivel)
+		if n >= capacidad {
+			n -= capacidad
+			continue
+		}
+
+		// Apuntador indirecto del nivel correspondiente
+		slot := directBlocks + nivel - 1
+		if inode.I_block[slot] == -1 {
+			nuevo, err := sb.newPointerBlock(path)
+			if err != nil {
+				return err
+			}
+			inode.I_block[slot] = nuevo
+		}
+
+		// Bajar por los bloques de apuntadores hasta el nivel de los datos
+		actual := inode.I_block[slot]
+		for l := nivel; l > 0; l-- {
+			pointerBlock := NewPointerBlock(sb.S_block_size)
+			err := pointerBlock.Deserialize(path, sb.BlockOffset(actual))
+			if err != nil {
+				return err
+			}
+			indice := n / sb.blocksPerLevel(l-1)
+			n %= sb.blocksPerLevel(l - 1)
+
+			if l == 1 {
+				pointerBlock.P_pointers[indice] = blockIndex
+				return pointerBlock.Serialize(path, sb.BlockOffset(actual))
+			}
+			if pointerBlock.P_pointers[indice] == -1 {
+				nuevo, err := sb.newPointerBlock(path)
+				if err != nil {
+					return err
+				}
+				pointerBlock.P_pointers[indice] = nuevo
+				err = pointerBlock.Serialize(path, sb.BlockOffset(actual))
+				if err != nil {
+					return err
+				}
+			}
+			actual = pointerBlock.P_pointers[indice]
+		}
+	}
+
+	return errors.New("el inodo ya no tiene apuntadores disponibles")
+}
+
+// ReadFileContent concatena el contenido de todos los bloques de datos del archivo
+func (sb *SuperBlock) ReadFileContent(path string, inode *Inode) (string, error) {
+	bloques, err := sb.GetInodeBlocks(path, inode)
+	if err != nil {
+		return "", err
+	}
+
+	var contenido strings.Builder
+	for _, blockIndex := range bloques {
+		fileBlock := NewFileBlock(sb.S_block_size)
+		err := fileBlock.Deserialize(path, sb.BlockOffset(blockIndex))
+		if err != nil {
+			return "", err
+		}
+		// Los bloques que no estan llenos terminan en caracteres nulos
+		contenido.WriteString(strings.TrimRight(string(fileBlock.B_content[:]), "\x00"))
+	}
+	return contenido.String(), nil
+}
+
+// Cantidad de bloques de apuntadores que necesita un archivo con n bloques de datos
+func (sb *SuperBlock) pointerBlocksFor(n int) int {
+	total := 0
+	n -= directBlocks
+	for nivel := 1; nivel <= 3 && n > 0; nivel++ {
+		enNivel := n
+		if enNivel > sb.blocksPerLevel(nivel) {
+			enNivel = sb.blocksPerLevel(nivel)
+		}
+		// Un bloque de apuntadores por cada grupo de hijos en cada nivel intermedio
+		for l := nivel; l > 0; l-- {
+			total += (enNivel + sb.blocksPerLevel(l) - 1) / sb.blocksPerLevel(l)
+		}
+		n -= enNivel
+	}
+	return total
+}
+
+// WriteFileContent reemplaza el contenido del archivo inodeIndex y serializa el inodo
+// Los bloques que sobran se liberan y los que faltan se reservan en el bitmap
+// El superbloque lo debe de serializar quien llama
+func (sb *SuperBlock) WriteFileContent(path string, inodeIndex int32, inode *Inode, contenido string) error {
+	// Se valida el espacio antes de liberar los bloques para no perder el contenido anterior
+	tamano := int(sb.S_block_size)
+	if len(contenido) > tamano*sb.MaxFileBlocks() {
+		return fmt.Errorf("el contenido es demasiado grande, maximo %d bytes", tamano*sb.MaxFileBlocks())
+	}
+	datos, apuntadores, err := sb.walkInodeBlocks(path, inode)
+	if err != nil {
+		return err
+	}
+	necesarios := (len(contenido) + tamano - 1) / tamano
+	necesarios += sb.pointerBlocksFor(necesarios)
+	if necesarios > int(sb.S_free_blocks_count)+len(datos)+len(apuntadores) {
+		return errors.New("no hay bloques libres suficientes en el sistema de archivos")
+	}
+
+	err = sb.FreeInodeBlocks(path, inode)
+	if err != nil {
+		return err
+	}
+
+	err = sb.writeFileBlocks(path, inode, contenido)
+	if err != nil {
+		return err
+	}
+
+	inode.touchModify()
+	return inode.Serialize(path, sb.InodeOffset(inodeIndex))
+}
+
+// FreeInodeBlocks libera los bloques de datos y de apuntadores del inodo y deja I_block vacío
+func (sb *SuperBlock) FreeInodeBlocks(path string, inode *Inode) error {
+	datos, apuntadores, err := sb.walkInodeBlocks(path, inode)
+	if err != nil {
+		return err
+	}
+	for _, blockIndex := range append(datos, apuntadores...) {
+		err := sb.FreeBlock(path, blockIndex)
+		if err != nil {
+			return fmt.Errorf("error al liberar el bloque %d: %w", blockIndex, err)
+		}
+	}
+	for i := range inode.I_block {
+		inode.I_block[i] = -1
+	}
+	inode.I_size = 0
+	return nil
+}
@@ -0,0 +1,111 @@
+package structures
+
+import (
+	"strings"
+	"testing"
+)
+
+// Valida con el fsck que el sistema de archivos no tenga problemas
+func assertConsistent(t *testing.T, sb *SuperBlock, path string) {
+	t.Helper()
+
+	issues, err := sb.CheckFilesystem(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range issues {
+		t.Errorf("[%s] %s", issue.Code, issue.Message)
+	}
+}
+
+func TestFileContentUsesIndirectBlocks(t *testing.T) {
+	sb, path := newTestFilesystem(t, 32, 64)
+
+	// 12 directos, 16 en el simple indirecto y 2 en el doble indirecto
+	bloques := directBlocks + sb.pointersPerBlock() + 2
+	contenido := strings.Repeat("0123456789", bloques*int(sb.S_block_size)/10)[:bloques*int(sb.S_block_size)-5]
+	libres := sb.S_free_blocks_count
+
+	err := sb.CreateFile(false, path, nil, "grande.txt", contenido, RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Los datos mas un bloque simple y dos del doble indirecto (el propio y uno de segundo nivel)
+	if usados := libres - sb.S_free_blocks_count; usados != int32(bloques+3) {
+		t.Errorf("se usaron %d bloques, se esperaba %d", usados, bloques+3)
+	}
+
+	leido, err := sb.GetFileContent(path, nil, "grande.txt", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leido != contenido {
+		t.Errorf("el contenido leido tiene %d bytes, se esperaba %d", len(leido), len(contenido))
+	}
+
+	inodeIndex, err := sb.ResolvePath(path, []string{"grande.txt"}, RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inode := &Inode{}
+	err = inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inode.I_block[12] == -1 || inode.I_block[13] == -1 || inode.I_block[14] != -1 {
+		t.Errorf("apuntadores indirectos %v, se esperaba simple y doble", inode.I_block[12:])
+	}
+	assertConsistent(t, sb, path)
+
+	// Al reducir el contenido se liberan los bloques de datos y de apuntadores que sobran
+	err = sb.WriteFileContent(path, inodeIndex, inode, "corto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sb.S_free_blocks_count != libres-1 {
+		t.Errorf("bloques libres %d, se esperaba %d", sb.S_free_blocks_count, libres-1)
+	}
+	if inode.I_block[12] != -1 || inode.I_block[13] != -1 {
+		t.Errorf("los apuntadores indirectos no se liberaron: %v", inode.I_block[12:])
+	}
+	leido, err = sb.ReadFileContent(path, inode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leido != "corto" {
+		t.Errorf("contenido %q, se esperaba %q", leido, "corto")
+	}
+	assertConsistent(t, sb, path)
+}
+
+func TestWriteFileContentRejectsTooLarge(t *testing.T) {
+	sb, path := newTestFilesystem(t, 8, 64)
+
+	inodeIndex, err := sb.ResolvePath(path, []string{"users.txt"}, RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inode := &Inode{}
+	err = inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		t.Fatal(err)
+	}
+	anterior, err := sb.ReadFileContent(path, inode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// No hay bloques suficientes, el contenido anterior no se pierde
+	err = sb.WriteFileContent(path, inodeIndex, inode, strings.Repeat("x", int(sb.S_block_size)*int(sb.TotalBlocks())))
+	if err == nil {
+		t.Fatal("se esperaba un error sin bloques suficientes")
+	}
+	leido, err := sb.ReadFileContent(path, inode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leido != anterior {
+		t.Errorf("contenido %q, se esperaba %q", leido, anterior)
+	}
+	assertConsistent(t, sb, path)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"io"
 	"os"
 	"time"
 )
@@ -12,13 +13,59 @@ type Inode struct {
 	I_uid   int32
 	I_gid   int32
 	I_size  int32
-	I_atime float32
-	I_ctime float32
-	I_mtime float32
+	I_atime int64 // Segundos Unix de la última lectura del contenido
+	I_ctime int64 // Segundos Unix del último cambio del inodo
+	I_mtime int64 // Segundos Unix de la última modificación del contenido
 	I_block [15]int32
-	I_type  [1]byte
+	I_type  [1]byte // '0' carpeta, '1' archivo, '2' enlace simbolico
 	I_perm  [3]byte
-	// Total: 88 bytes
+	I_links int32 // Cantidad de entradas de carpeta que apuntan al inodo
+	// Total: 104 bytes
+}
+
+// Uid del usuario root, el root no tiene restricciones de permisos
+const RootUID int32 = 1
+
+// Bits de los permisos UGO
+const (
+	PermRead  byte = 4
+	PermWrite byte = 2
+	PermExec  byte = 1
+)
+
+// HasPermission valida si el usuario uid con grupo gid tiene el permiso indicado sobre el inodo
+// Se usa el digito del propietario, del grupo o de otros segun corresponda
+func (inode *Inode) HasPermission(uid int32, gid int32, permiso byte) bool {
+	if uid == RootUID {
+		return true
+	}
+	digito := inode.I_perm[2]
+	if inode.I_uid == uid {
+		digito = inode.I_perm[0]
+	} else if inode.I_gid == gid {
+		digito = inode.I_perm[1]
+	}
+	return (digito-'0')&permiso != 0
+}
+
+// Las fechas se actualizan como en POSIX: atime al leer el contenido, mtime al modificarlo
+// y ctime con cualquier cambio del inodo (contenido, permisos, propietario, nombre o enlaces)
+
+// touchAccess marca la lectura del contenido
+func (inode *Inode) touchAccess() {
+	inode.I_atime = time.Now().Unix()
+}
+
+// touchModify marca la modificación del contenido, también cambia ctime
+func (inode *Inode) touchModify() {
+	ahora := time.Now().Unix()
+	inode.I_mtime = ahora
+	inode.I_ctime = ahora
+}
+
+// touchChange marca un cambio del inodo que no modifica el contenido
+func (inode *Inode) touchChange() {
+	inode.I_ctime = time.Now().Unix()
 }
 
 // Serialize escribe la estructura Inode en un archivo binario en la posición especificada
@@ -66,7 +113,7 @@ func (inode *Inode) Deserialize(path string, offset int64) error {
 
 	// Leer solo la cantidad de bytes que corresponden al tamaño de la estructura Inode
 	buffer := make([]byte, inodeSize)
-	_, err = file.Read(buffer)
+	_, err = io.ReadFull(file, buffer)
 	if err != nil {
 		return err
 	}
@@ -83,9 +130,9 @@ func (inode *Inode) Deserialize(path string, offset int64) error {
 
 // Print imprime los atributos del inodo
 func (inode *Inode) Print() {
-	atime := time.Unix(int64(inode.I_atime), 0)
-	ctime := time.Unix(int64(inode.I_ctime), 0)
-	mtime := time.Unix(int64(inode.I_mtime), 0)
+	atime := time.Unix(inode.I_atime, 0)
+	ctime := time.Unix(inode.I_ctime, 0)
+	mtime := time.Unix(inode.I_mtime, 0)
 
 	fmt.Printf("I_uid: %d\n", inode.I_uid)
 	fmt.Printf("I_gid: %d\n", inode.I_gid)
@@ -96,4 +143,5 @@ func (inode *Inode) Print() {
 	fmt.Printf("I_block: %v\n", inode.I_block)
 	fmt.Printf("I_type: %s\n", string(inode.I_type[:]))
 	fmt.Printf("I_perm: %s\n", string(inode.I_perm[:]))
+	fmt.Printf("I_links: %d\n", inode.I_links)
 }
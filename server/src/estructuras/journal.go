@@ -0,0 +1,252 @@
+package structures
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	utils "bakend/src/utils"
+)
+
+type Information struct {
+	I_operation [10]byte
+	I_path      [64]byte
+	I_content   [64]byte
+	I_date      int64 // Segundos Unix de la operación
+	I_uid       int32
+	I_gid       int32
+	// Total: 154 bytes
+}
+
+type Journal struct {
+	J_count   int32 // Número de la entrada, 0 si la entrada esta vacía
+	J_content Information
+	// Total: 158 bytes
+}
+
+// Operación de las entradas que continúan el contenido de la entrada anterior
+const journalContinuation = "+"
+
+// JournalEntry es una operación del journal con su contenido completo
+type JournalEntry struct {
+	Operacion string
+	Ruta      string
+	Contenido string
+	Fecha     time.Time
+	Uid       int32
+	Gid       int32
+}
+
+// Serialize escribe la estructura Journal en un archivo binario en la posición especificada
+func (journal *Journal) Serialize(path string, offset int64) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Mover el puntero del archivo a la posición especificada
+	_, err = file.Seek(offset, 0)
+	if err != nil {
+		return err
+	}
+
+	// Serializar la estructura Journal directamente en el archivo
+	err = binary.Write(file, binary.LittleEndian, journal)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Deserialize lee la estructura Journal desde un archivo binario en la posición especificada
+func (journal *Journal) Deserialize(path string, offset int64) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Mover el puntero del archivo a la posición especificada
+	_, err = file.Seek(offset, 0)
+	if err != nil {
+		return err
+	}
+
+	// Obtener el tamaño de la estructura Journal
+	journalSize := binary.Size(journal)
+	if journalSize <= 0 {
+		return fmt.Errorf("invalid Journal size: %d", journalSize)
+	}
+
+	// Leer solo la cantidad de bytes que corresponden al tamaño de la estructura Journal
+	buffer := make([]byte, journalSize)
+	_, err = io.ReadFull(file, buffer)
+	if err != nil {
+		return err
+	}
+
+	// Deserializar los bytes leídos en la estructura Journal
+	reader := bytes.NewReader(buffer)
+	err = binary.Read(reader, binary.LittleEndian, journal)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IsExt3 indica si el sistema de archivos tiene journal
+func (sb *SuperBlock) IsExt3() bool {
+	return sb.S_filesystem_type == 3
+}
+
+// El journal tiene una entrada por cada inodo y termina donde inicia el bitmap de inodos
+func (sb *SuperBlock) journalOffset(index int32) int64 {
+	journalSize := int64(binary.Size(Journal{}))
+	inicio := int64(sb.S_bm_inode_start) - int64(sb.TotalInodes())*journalSize
+	return inicio + int64(index)*journalSize
+}
+
+// CreateJournal deja vacías todas las entradas del journal
+func (sb *SuperBlock) CreateJournal(path string) error {
+	return utils.ZeroFillRange(path, sb.journalOffset(0), int64(sb.TotalInodes())*int64(binary.Size(Journal{})))
+}
+
+// AddJournal registra una operación en el journal, si el sistema de archivos es ext2 no hace nada
+func (sb *SuperBlock) AddJournal(path string, operacion string, ruta string, contenido string, uid int32, gid int32) error {
+	if !sb.IsExt3() {
+		return nil
+	}
+	return sb.addJournalEntry(path, JournalEntry{Operacion: operacion, Ruta: ruta, Contenido: contenido, Fecha: time.Now(), Uid: uid, Gid: gid})
+}
+
+// Escribe la operación en las primeras entradas vacías del journal conservando su fecha
+// El contenido que no cabe en una entrada se guarda en las entradas siguientes
+func (sb *SuperBlock) addJournalEntry(path string, entrada JournalEntry) error {
+	operacion, ruta, contenido := entrada.Operacion, entrada.Ruta, entrada.Contenido
+	if len(ruta) > len(Information{}.I_path) {
+		return fmt.Errorf("error la ruta %s es demasiado larga para el journal", ruta)
+	}
+
+	// Buscar la primera entrada vacía
+	total := sb.TotalInodes()
+	libre := int32(-1)
+	for i := int32(0); i < total; i++ {
+		journal := &Journal{}
+		err := journal.Deserialize(path, sb.journalOffset(i))
+		if err != nil {
+			return err
+		}
+		if journal.J_count == 0 {
+			libre = i
+			break
+		}
+	}
+
+	// Dividir el contenido en partes del tamaño de I_content
+	tamano := len(Information{}.I_content)
+	partes := []string{""}
+	if contenido != "" {
+		partes = nil
+		for inicio := 0; inicio < len(contenido); inicio += tamano {
+			fin := min(inicio+tamano, len(contenido))
+			partes = append(partes, contenido[inicio:fin])
+		}
+	}
+	if libre == -1 || libre+int32(len(partes)) > total {
+		return errors.New("error el journal esta lleno")
+	}
+
+	for i, parte := range partes {
+		journal := &Journal{J_count: libre + int32(i) + 1}
+		journal.J_content.I_date = entrada.Fecha.Unix()
+		journal.J_content.I_uid = entrada.Uid
+		journal.J_content.I_gid = entrada.Gid
+		if i == 0 {
+			copy(journal.J_content.I_operation[:], operacion)
+			copy(journal.J_content.I_path[:], ruta)
+		} else {
+			copy(journal.J_content.I_operation[:], journalContinuation)
+		}
+		copy(journal.J_content.I_content[:], parte)
+
+		err := journal.Serialize(path, sb.journalOffset(libre+int32(i)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetJournal retorna en orden las operaciones registradas en el journal
+func (sb *SuperBlock) GetJournal(path string) ([]JournalEntry, error) {
+	if !sb.IsExt3() {
+		return nil, errors.New("error el sistema de archivos no es ext3")
+	}
+
+	var entradas []JournalEntry
+	for i := int32(0); i < sb.TotalInodes(); i++ {
+		journal := &Journal{}
+		err := journal.Deserialize(path, sb.journalOffset(i))
+		if err != nil {
+			return nil, err
+		}
+		if journal.J_count == 0 {
+			break
+		}
+		entradas = appendJournal(entradas, journal.J_content)
+	}
+	return entradas, nil
+}
+
+// Agrega la entrada del journal a las operaciones, las entradas de continuación
+// completan el contenido de la operación anterior
+func appendJournal(entradas []JournalEntry, info Information) []JournalEntry {
+	operacion := strings.TrimRight(string(info.I_operation[:]), "\x00")
+	contenido := strings.TrimRight(string(info.I_content[:]), "\x00")
+	if operacion == journalContinuation && len(entradas) > 0 {
+		entradas[len(entradas)-1].Contenido += contenido
+		return entradas
+	}
+	return append(entradas, JournalEntry{
+		Operacion: operacion,
+		Ruta:      strings.TrimRight(string(info.I_path[:]), "\x00"),
+		Contenido: contenido,
+		Fecha:     time.Unix(info.I_date, 0),
+		Uid:       info.I_uid,
+		Gid:       info.I_gid,
+	})
+}
+
+// WipeFilesystem simula la perdida del sistema de archivos llenando con ceros
+// los bitmaps, la tabla de inodos y los bloques, el superbloque y el journal se conservan
+func (sb *SuperBlock) WipeFilesystem(path string) error {
+	// Desde el bitmap de inodos hasta el final del último bloque
+	fin := int64(sb.S_block_start) + int64(sb.TotalBlocks())*int64(sb.S_block_size)
+	return utils.ZeroFillRange(path, int64(sb.S_bm_inode_start), fin-int64(sb.S_bm_inode_start))
+}
+
+// ResetFilesystem deja el sistema de archivos como recien formateado, solo con la raíz y users.txt
+func (sb *SuperBlock) ResetFilesystem(path string) error {
+	totalInodos := sb.TotalInodes()
+	totalBloques := sb.TotalBlocks()
+	sb.S_inodes_count = 0
+	sb.S_blocks_count = 0
+	sb.S_free_inodes_count = totalInodos
+	sb.S_free_blocks_count = totalBloques
+	sb.S_first_ino = sb.S_inode_start
+	sb.S_first_blo = sb.S_block_start
+
+	err := sb.CreateBitMaps(path)
+	if err != nil {
+		return err
+	}
+	return sb.CreateUsersFile(path)
+}
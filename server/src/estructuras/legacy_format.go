@@ -0,0 +1,360 @@
+package structures
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// FormatVersion es la versión del formato en disco que escribe mkfs
+// Versión 1: formato original, fechas float32 y sin S_version, inodos de 88 bytes
+// o de 92 bytes con I_links en los discos formateados despues de agregar los enlaces
+// Versión 2: fechas int64 en el superbloque, los inodos y el journal
+const FormatVersion int32 = 2
+
+// ErrLegacyFormat indica que la partición tiene un sistema de archivos de la versión 1
+var ErrLegacyFormat = errors.New("error el sistema de archivos usa el formato anterior, utilice el comando migrate para actualizarlo")
+
+// Superbloque de la versión 1
+type legacySuperBlock struct {
+	S_filesystem_type   int32
+	S_inodes_count      int32
+	S_blocks_count      int32
+	S_free_inodes_count int32
+	S_free_blocks_count int32
+	S_mtime             float32
+	S_umtime            float32
+	S_mnt_count         int32
+	S_magic             int32
+	S_inode_size        int32
+	S_block_size        int32
+	S_first_ino         int32
+	S_first_blo         int32
+	S_bm_inode_start    int32
+	S_bm_block_start    int32
+	S_inode_start       int32
+	S_block_start       int32
+	// Total: 68 bytes
+}
+
+// Inodo de la versión 1, los bloques no cambiaron de formato
+// No tiene I_links, la cantidad de enlaces se obtiene contando las entradas que apuntan a cada inodo
+// Los inodos de 92 bytes tienen I_links al final, se ubican con S_inode_size y esos bytes se ignoran
+type legacyInode struct {
+	I_uid   int32
+	I_gid   int32
+	I_size  int32
+	I_atime float32
+	I_ctime float32
+	I_mtime float32
+	I_block [15]int32
+	I_type  [1]byte
+	I_perm  [3]byte
+	// Total: 88 bytes
+}
+
+// Entrada del journal de la versión 1
+type legacyJournal struct {
+	J_count   int32
+	J_content struct {
+		I_operation [10]byte
+		I_path      [64]byte
+		I_content   [64]byte
+		I_date      float32
+		I_uid       int32
+		I_gid       int32
+	}
+	// Total: 154 bytes
+}
+
+// En la versión 2 el número mágico de la versión 1 cae en la parte alta de S_umtime, que es cero,
+// por eso si el buffer tiene el número mágico en esa posición el superbloque es de la versión 1
+func isLegacyFormat(buffer []byte) bool {
+	legacy := legacySuperBlock{}
+	err := binary.Read(bytes.NewReader(buffer), binary.LittleEndian, &legacy)
+	return err == nil && legacy.S_magic == 0xEF53
+}
+
+// Valida el tamaño de inodo de la versión 1, con o sin I_links
+func isLegacyInodeSize(size int32) bool {
+	base := int32(binary.Size(legacyInode{}))
+	return size == base || size == base+int32(binary.Size(int32(0)))
+}
+
+// Lee la estructura data en la posición offset del archivo
+func readLegacy(path string, offset int64, data any) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Seek(offset, 0)
+	if err != nil {
+		return err
+	}
+	buffer := make([]byte, binary.Size(data))
+	_, err = io.ReadFull(file, buffer)
+	if err != nil {
+		return err
+	}
+	return binary.Read(bytes.NewReader(buffer), binary.LittleEndian, data)
+}
+
+// LegacyFilesystem es el contenido completo de un sistema de archivos de la versión 1
+// Solo se conservan los inodos alcanzables desde la raíz, igual que en fsck
+type LegacyFilesystem struct {
+	FilesystemType int32 // 2 o 3
+	BlockSize      int32
+	Ratio          int32 // Bloques por cada inodo
+	mtime          int64
+	umtime         int64
+	mntCount       int32
+	nodos          map[int32]*legacyNode
+	enlaces        map[int32]int32 // Entradas que apuntan a cada inodo
+	bloques        int32           // Bloques de datos y de apuntadores que se usan
+	journal        []JournalEntry
+	filasJournal   int32 // Entradas del journal que se usan
+}
+
+// Inodo de la versión 1 convertido, con su contenido o sus entradas
+type legacyNode struct {
+	inode     Inode
+	contenido string
+	hijos     []FolderEntry
+}
+
+// ReadLegacyFilesystem lee a memoria el sistema de archivos de la versión 1 que inicia en offset
+func ReadLegacyFilesystem(path string, offset int64) (*LegacyFilesystem, error) {
+	buffer := make([]byte, binary.Size(SuperBlock{}))
+	err := readLegacy(path, offset, buffer)
+	if err != nil {
+		return nil, err
+	}
+	if !isLegacyFormat(buffer) {
+		actual := SuperBlock{}
+		err := binary.Read(bytes.NewReader(buffer), binary.LittleEndian, &actual)
+		if err == nil && actual.S_magic == 0xEF53 {
+			return nil, fmt.Errorf("error el sistema de archivos ya usa el formato %d", actual.S_version)
+		}
+		return nil, errors.New("error la partición no tiene un sistema de archivos")
+	}
+
+	legacySB := legacySuperBlock{}
+	err = binary.Read(bytes.NewReader(buffer), binary.LittleEndian, &legacySB)
+	if err != nil {
+		return nil, err
+	}
+	if !isLegacyInodeSize(legacySB.S_inode_size) {
+		return nil, fmt.Errorf("error el tamaño de inodo %d no corresponde a la versión 1", legacySB.S_inode_size)
+	}
+
+	// Superbloque con la distribución anterior para usar las funciones de bloques
+	sb := &SuperBlock{
+		S_inode_size:     legacySB.S_inode_size,
+		S_block_size:     legacySB.S_block_size,
+		S_bm_inode_start: legacySB.S_bm_inode_start,
+		S_bm_block_start: legacySB.S_bm_block_start,
+		S_inode_start:    legacySB.S_inode_start,
+		S_block_start:    legacySB.S_block_start,
+	}
+	totalInodos := legacySB.S_bm_block_start - legacySB.S_bm_inode_start
+	legacy := &LegacyFilesystem{
+		FilesystemType: legacySB.S_filesystem_type,
+		BlockSize:      legacySB.S_block_size,
+		Ratio:          (legacySB.S_inode_start - legacySB.S_bm_block_start) / totalInodos,
+		mtime:          int64(legacySB.S_mtime),
+		umtime:         int64(legacySB.S_umtime),
+		mntCount:       legacySB.S_mnt_count,
+		nodos:          make(map[int32]*legacyNode),
+		enlaces:        make(map[int32]int32),
+	}
+
+	err = legacy.readNode(path, sb, 0, totalInodos)
+	if err != nil {
+		return nil, err
+	}
+
+	if legacy.FilesystemType == 3 {
+		err = legacy.readJournal(path, legacySB, totalInodos)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return legacy, nil
+}
+
+// Lee el inodo y, si es una carpeta, todo su contenido
+func (legacy *LegacyFilesystem) readNode(path string, sb *SuperBlock, inodeIndex int32, totalInodos int32) error {
+	legacy.enlaces[inodeIndex]++
+	if legacy.nodos[inodeIndex] != nil {
+		return nil
+	}
+	if inodeIndex < 0 || inodeIndex >= totalInodos {
+		return fmt.Errorf("error el inodo %d esta fuera de rango, utilice fsck antes de migrar", inodeIndex)
+	}
+
+	anterior := legacyInode{}
+	err := readLegacy(path, sb.InodeOffset(inodeIndex), &anterior)
+	if err != nil {
+		return err
+	}
+	nodo := &legacyNode{inode: Inode{
+		I_uid:   anterior.I_uid,
+		I_gid:   anterior.I_gid,
+		I_size:  anterior.I_size,
+		I_atime: int64(anterior.I_atime),
+		I_ctime: int64(anterior.I_ctime),
+		I_mtime: int64(anterior.I_mtime),
+		I_block: anterior.I_block,
+		I_type:  anterior.I_type,
+		I_perm:  anterior.I_perm,
+	}}
+	legacy.nodos[inodeIndex] = nodo
+
+	datos, apuntadores, err := sb.walkInodeBlocks(path, &nodo.inode)
+	if err != nil {
+		return err
+	}
+	legacy.bloques += int32(len(datos) + len(apuntadores))
+
+	if nodo.inode.I_type[0] != '0' {
+		nodo.contenido, err = sb.ReadFileContent(path, &nodo.inode)
+		return err
+	}
+
+	for _, blockIndex := range datos {
+		block := NewFolderBlock(sb.S_block_size)
+		err := block.Deserialize(path, sb.BlockOffset(blockIndex))
+		if err != nil {
+			return err
+		}
+		nodo.hijos = append(nodo.hijos, block.Entries()...)
+	}
+	for _, hijo := range nodo.hijos {
+		err := legacy.readNode(path, sb, hijo.B_inodo, totalInodos)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Lee las operaciones del journal de la versión 1, que esta antes del bitmap de inodos
+func (legacy *LegacyFilesystem) readJournal(path string, legacySB legacySuperBlock, totalInodos int32) error {
+	tamano := int64(binary.Size(legacyJournal{}))
+	inicio := int64(legacySB.S_bm_inode_start) - int64(totalInodos)*tamano
+	for i := int32(0); i < totalInodos; i++ {
+		anterior := legacyJournal{}
+		err := readLegacy(path, inicio+int64(i)*tamano, &anterior)
+		if err != nil {
+			return err
+		}
+		if anterior.J_count == 0 {
+			break
+		}
+
+		info := anterior.J_content
+		legacy.journal = appendJournal(legacy.journal, Information{
+			I_operation: info.I_operation,
+			I_path:      info.I_path,
+			I_content:   info.I_content,
+			I_date:      int64(info.I_date),
+			I_uid:       info.I_uid,
+			I_gid:       info.I_gid,
+		})
+		legacy.filasJournal++
+	}
+	return nil
+}
+
+// Fits valida que el contenido quepa en un sistema de archivos de n inodos con el formato actual
+func (legacy *LegacyFilesystem) Fits(n int32) error {
+	if int32(len(legacy.nodos)) > n || legacy.bloques > n*legacy.Ratio || legacy.filasJournal > n {
+		return fmt.Errorf("error el contenido no cabe en el formato actual: usa %d inodos y %d bloques, el nuevo formato tiene %d inodos y %d bloques",
+			len(legacy.nodos), legacy.bloques, n, n*legacy.Ratio)
+	}
+	return nil
+}
+
+// RestoreLegacy crea en el sistema de archivos recien formateado el contenido leido de la versión 1
+// Los inodos y bloques se reservan de nuevo, se conservan los propietarios, permisos, fechas y enlaces
+// El superbloque lo debe de serializar quien llama
+func (sb *SuperBlock) RestoreLegacy(path string, legacy *LegacyFilesystem) error {
+	raiz := legacy.nodos[0]
+	nuevaRaiz, err := sb.newFolderInode(path, -1, raiz.inode.I_perm, raiz.inode.I_uid, raiz.inode.I_gid)
+	if err != nil {
+		return err
+	}
+	creados := map[int32]int32{0: nuevaRaiz}
+	err = sb.restoreChildren(path, legacy, 0, creados)
+	if err != nil {
+		return err
+	}
+
+	// Las fechas y los enlaces se asignan al final porque agregar entradas cambia las fechas de las carpetas
+	for anterior, nuevo := range creados {
+		inode := &Inode{}
+		err := inode.Deserialize(path, sb.InodeOffset(nuevo))
+		if err != nil {
+			return err
+		}
+		original := legacy.nodos[anterior].inode
+		inode.I_atime = original.I_atime
+		inode.I_ctime = original.I_ctime
+		inode.I_mtime = original.I_mtime
+		inode.I_links = legacy.enlaces[anterior]
+		err = inode.Serialize(path, sb.InodeOffset(nuevo))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, entrada := range legacy.journal {
+		err := sb.addJournalEntry(path, entrada)
+		if err != nil {
+			return err
+		}
+	}
+
+	sb.S_mtime = legacy.mtime
+	sb.S_umtime = legacy.umtime
+	sb.S_mnt_count = legacy.mntCount
+	return nil
+}
+
+// Crea las entradas de la carpeta anterior dentro de la carpeta nueva, un inodo que ya se creo es un enlace duro
+func (sb *SuperBlock) restoreChildren(path string, legacy *LegacyFilesystem, anterior int32, creados map[int32]int32) error {
+	carpeta := creados[anterior]
+	for _, hijo := range legacy.nodos[anterior].hijos {
+		nuevo, existe := creados[hijo.B_inodo]
+		if !existe {
+			nodo := legacy.nodos[hijo.B_inodo]
+			var err error
+			if nodo.inode.I_type[0] == '0' {
+				nuevo, err = sb.newFolderInode(path, carpeta, nodo.inode.I_perm, nodo.inode.I_uid, nodo.inode.I_gid)
+			} else {
+				nuevo, err = sb.newDataInode(path, nodo.inode.I_type[0], nodo.contenido, nodo.inode.I_perm, nodo.inode.I_uid, nodo.inode.I_gid)
+			}
+			if err != nil {
+				return err
+			}
+			creados[hijo.B_inodo] = nuevo
+		}
+
+		err := sb.addFolderEntry(path, carpeta, hijo.GetName(), nuevo)
+		if err != nil {
+			return err
+		}
+		if !existe && legacy.nodos[hijo.B_inodo].inode.I_type[0] == '0' {
+			err = sb.restoreChildren(path, legacy, hijo.B_inodo, creados)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
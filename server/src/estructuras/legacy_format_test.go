@@ -0,0 +1,191 @@
+package structures
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Fecha de los inodos de la versión 1, se puede representar exacta en float32
+const legacyTestTime float32 = 1700000000
+
+// Escribe data en la posición offset del archivo
+func writeLegacy(t *testing.T, path string, offset int64, data any) {
+	t.Helper()
+
+	file, err := os.OpenFile(path, os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	_, err = file.Seek(offset, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = binary.Write(file, binary.LittleEndian, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Crea un disco con un sistema de archivos ext2 de la versión 1 de 8 inodos y bloques de 64 bytes
+// Contiene /users.txt y /docs/a.txt, con /docs/enlace como enlace duro de a.txt
+func newLegacyFilesystem(t *testing.T, inodeSize int32) string {
+	t.Helper()
+
+	n, ratio, blockSize := int32(8), int32(3), int32(64)
+	bmInodeStart := int32(binary.Size(legacySuperBlock{}))
+	bmBlockStart := bmInodeStart + n
+	inodeStart := bmBlockStart + ratio*n
+	blockStart := inodeStart + inodeSize*n
+
+	path := filepath.Join(t.TempDir(), "anterior.mia")
+	err := os.WriteFile(path, make([]byte, blockStart+ratio*n*blockSize), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeLegacy(t, path, 0, legacySuperBlock{
+		S_filesystem_type:   2,
+		S_inodes_count:      4,
+		S_blocks_count:      4,
+		S_free_inodes_count: n - 4,
+		S_free_blocks_count: ratio*n - 4,
+		S_mtime:             legacyTestTime,
+		S_umtime:            legacyTestTime,
+		S_mnt_count:         3,
+		S_magic:             0xEF53,
+		S_inode_size:        inodeSize,
+		S_block_size:        blockSize,
+		S_first_ino:         inodeStart + 4*inodeSize,
+		S_first_blo:         blockStart + 4*blockSize,
+		S_bm_inode_start:    bmInodeStart,
+		S_bm_block_start:    bmBlockStart,
+		S_inode_start:       inodeStart,
+		S_block_start:       blockStart,
+	})
+	writeLegacy(t, path, int64(bmInodeStart), []byte(strings.Repeat("1", 4)+strings.Repeat("0", int(n-4))))
+	writeLegacy(t, path, int64(bmBlockStart), []byte(strings.Repeat("1", 4)+strings.Repeat("0", int(ratio*n-4))))
+
+	sb := &SuperBlock{S_block_size: blockSize}
+	contenidos := []string{"", "1,G,root\n1,U,root,root,123\n", "", "hola mundo"}
+	for i, contenido := range contenidos {
+		inode := legacyInode{
+			I_uid:   RootUID,
+			I_gid:   RootUID,
+			I_size:  int32(len(contenido)),
+			I_atime: legacyTestTime,
+			I_ctime: legacyTestTime,
+			I_mtime: legacyTestTime,
+			I_type:  [1]byte{'1'},
+			I_perm:  [3]byte{'6', '6', '4'},
+		}
+		for j := range inode.I_block {
+			inode.I_block[j] = -1
+		}
+		inode.I_block[0] = int32(i)
+		if i == 0 || i == 2 {
+			inode.I_type, inode.I_perm = [1]byte{'0'}, [3]byte{'7', '7', '5'}
+		}
+		writeLegacy(t, path, int64(inodeStart+int32(i)*inodeSize), inode)
+
+		offset := int64(blockStart + int32(i)*blockSize)
+		if inode.I_type[0] == '1' {
+			block := NewFileBlock(blockSize)
+			copy(block.B_content, contenido)
+			writeLegacy(t, path, offset, block.B_content)
+		}
+	}
+
+	// Los discos de la versión 1 tienen . y .. en el primer bloque de cada carpeta
+	raiz := sb.newFolderBlock(0, 0)
+	raiz.setEntry(2, "users.txt", 1)
+	raiz.setEntry(3, "docs", 2)
+	writeLegacy(t, path, int64(blockStart), raiz.B_content)
+	docs := sb.newFolderBlock(2, 0)
+	docs.setEntry(2, "a.txt", 3)
+	docs.setEntry(3, "enlace", 3)
+	writeLegacy(t, path, int64(blockStart+2*blockSize), docs.B_content)
+	return path
+}
+
+func TestMigrateLegacyFilesystem(t *testing.T) {
+	base := int32(binary.Size(legacyInode{}))
+	pruebas := []struct {
+		nombre    string
+		inodeSize int32
+	}{
+		{"sin I_links", base},
+		{"con I_links", base + 4},
+	}
+	for _, prueba := range pruebas {
+		t.Run(prueba.nombre, func(t *testing.T) {
+			anterior := newLegacyFilesystem(t, prueba.inodeSize)
+			legacy, err := ReadLegacyFilesystem(anterior, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if legacy.FilesystemType != 2 || legacy.BlockSize != 64 || legacy.Ratio != 3 {
+				t.Fatalf("ext%d con bloques de %d y ratio %d, se esperaba ext2, 64 y 3", legacy.FilesystemType, legacy.BlockSize, legacy.Ratio)
+			}
+			err = legacy.Fits(16)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sb, path := newTestSuperBlock(t, 16, 64)
+			err = sb.RestoreLegacy(path, legacy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertConsistent(t, sb, path)
+
+			if sb.S_mnt_count != 3 || sb.S_mtime != int64(legacyTestTime) {
+				t.Errorf("S_mnt_count %d y S_mtime %d, se esperaba 3 y %d", sb.S_mnt_count, sb.S_mtime, int64(legacyTestTime))
+			}
+			contenido, err := sb.GetFileContent(path, []string{"docs"}, "a.txt", RootUID, RootUID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if contenido != "hola mundo" {
+				t.Errorf("contenido %q, se esperaba %q", contenido, "hola mundo")
+			}
+			usuarios, err := sb.GetFileContent(path, nil, "users.txt", RootUID, RootUID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if usuarios != "1,G,root\n1,U,root,root,123\n" {
+				t.Errorf("users.txt %q", usuarios)
+			}
+
+			// El enlace duro sigue apuntando al mismo inodo y se conservan las fechas
+			archivo := mustResolve(t, sb, path, "docs", "a.txt")
+			if enlace := mustResolve(t, sb, path, "docs", "enlace"); enlace != archivo {
+				t.Errorf("el enlace apunta al inodo %d, se esperaba %d", enlace, archivo)
+			}
+			inode := &Inode{}
+			err = inode.Deserialize(path, sb.InodeOffset(archivo))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if inode.I_links != 2 || inode.I_mtime != int64(legacyTestTime) {
+				t.Errorf("I_links %d e I_mtime %d, se esperaba 2 y %d", inode.I_links, inode.I_mtime, int64(legacyTestTime))
+			}
+		})
+	}
+}
+
+func TestReadLegacyRejectsCurrentFormat(t *testing.T) {
+	sb, path := newTestFilesystem(t, 16, 64)
+	err := sb.Serialize(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ReadLegacyFilesystem(path, 0)
+	if err == nil || !strings.Contains(err.Error(), "ya usa el formato") {
+		t.Fatalf("se esperaba el error del formato actual, se obtuvo %v", err)
+	}
+}
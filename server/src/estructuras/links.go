@@ -0,0 +1,179 @@
+package structures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Cantidad máxima de enlaces simbolicos que se siguen al resolver una ruta, evita los ciclos
+const maxSymlinks = 8
+
+// Busca la entrada name en la carpeta folderIndex y sigue los enlaces simbolicos, saltos cuenta los enlaces seguidos
+func (sb *SuperBlock) findFollowing(path string, folderIndex int32, name string, saltos *int, uid int32, gid int32) (int32, error) {
+	inodeIndex, err := sb.lookupEntry(path, folderIndex, name, uid, gid)
+	if err != nil || inodeIndex == -1 {
+		return inodeIndex, err
+	}
+	return sb.followSymlink(path, folderIndex, inodeIndex, saltos, uid, gid)
+}
+
+// Si el inodo es un enlace simbolico retorna el inodo al que apunta, si no retorna el mismo inodo
+// Las rutas relativas del enlace se resuelven desde la carpeta folderIndex donde esta el enlace
+func (sb *SuperBlock) followSymlink(path string, folderIndex int32, inodeIndex int32, saltos *int, uid int32, gid int32) (int32, error) {
+	inode := &Inode{}
+	err := inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		return -1, err
+	}
+	if inode.I_type[0] != '2' {
+		return inodeIndex, nil
+	}
+
+	*saltos++
+	if *saltos > maxSymlinks {
+		return -1, errors.New("error demasiados niveles de enlaces simbolicos, puede haber un ciclo")
+	}
+
+	destino, err := sb.ReadFileContent(path, inode)
+	if err != nil {
+		return -1, err
+	}
+
+	posicion := folderIndex
+	if strings.HasPrefix(destino, "/") {
+		posicion = 0
+	}
+	for _, component := range strings.Split(destino, "/") {
+		switch component {
+		case "", ".":
+			continue
+		case "..":
+			err = sb.checkSearch(path, posicion, uid, gid)
+			if err != nil {
+				return -1, err
+			}
+			posicion, err = sb.parentFolder(path, posicion)
+			if err != nil {
+				return -1, err
+			}
+		default:
+			siguiente, err := sb.findFollowing(path, posicion, component, saltos, uid, gid)
+			if err != nil {
+				return -1, err
+			}
+			if siguiente == -1 {
+				return -1, fmt.Errorf("error el enlace apunta a %s que no existe", destino)
+			}
+			posicion = siguiente
+		}
+	}
+	return posicion, nil
+}
+
+// Retorna la carpeta padre de folderIndex, se obtiene del .. de su primer bloque
+func (sb *SuperBlock) parentFolder(path string, folderIndex int32) (int32, error) {
+	inode := &Inode{}
+	err := inode.Deserialize(path, sb.InodeOffset(folderIndex))
+	if err != nil {
+		return -1, err
+	}
+	if inode.I_type[0] != '0' {
+		return -1, errors.New("error los directorios de la ruta es un archivo")
+	}
+	block := NewFolderBlock(sb.S_block_size)
+	err = block.Deserialize(path, sb.BlockOffset(inode.I_block[0]))
+	if err != nil {
+		return -1, err
+	}
+	return block.B_content[1].B_inodo, nil
+}
+
+// Resuelve la carpeta donde se crea el enlace y valida que se pueda agregar la entrada name
+func (sb *SuperBlock) resolveLinkFolder(path string, parentsDir []string, name string, uid int32, gid int32) (int32, error) {
+	if name == "" {
+		return -1, errors.New("error el destino del enlace no puede ser la carpeta raíz")
+	}
+	folderIndex, err := sb.ResolvePath(path, parentsDir, uid, gid)
+	if err != nil {
+		return -1, err
+	}
+
+	folder := &Inode{}
+	err = folder.Deserialize(path, sb.InodeOffset(folderIndex))
+	if err != nil {
+		return -1, err
+	}
+	if folder.I_type[0] != '0' {
+		return -1, errors.New("error la carpeta del enlace no es una carpeta")
+	}
+	if !folder.HasPermission(uid, gid, PermWrite) {
+		return -1, errors.New("error no tiene permiso de escritura sobre la carpeta del enlace")
+	}
+
+	existente, err := sb.lookupEntry(path, folderIndex, name, uid, gid)
+	if err != nil {
+		return -1, err
+	}
+	if existente != -1 {
+		return -1, fmt.Errorf("error ya existe %s en la carpeta", name)
+	}
+	return folderIndex, nil
+}
+
+// HardLink agrega la entrada name en la carpeta parentsDir apuntando al archivo target y aumenta su I_links
+// Solo se pueden crear enlaces duros a archivos
+// El superbloque lo debe de serializar quien llama
+func (sb *SuperBlock) HardLink(path string, target []string, parentsDir []string, name string, uid int32, gid int32) error {
+	targetIndex, err := sb.ResolvePath(path, target, uid, gid)
+	if err != nil {
+		return err
+	}
+	inode := &Inode{}
+	err = inode.Deserialize(path, sb.InodeOffset(targetIndex))
+	if err != nil {
+		return err
+	}
+	if inode.I_type[0] != '1' {
+		return errors.New("error solo se pueden crear enlaces duros a archivos")
+	}
+
+	folderIndex, err := sb.resolveLinkFolder(path, parentsDir, name, uid, gid)
+	if err != nil {
+		return err
+	}
+	err = sb.addFolderEntry(path, folderIndex, name, targetIndex)
+	if err != nil {
+		return err
+	}
+
+	inode.I_links++
+	inode.touchChange()
+	return inode.Serialize(path, sb.InodeOffset(targetIndex))
+}
+
+// Symlink crea el enlace simbolico name en la carpeta parentsDir, su bloque guarda la ruta destino
+// La ruta destino no tiene que existir, se resuelve cada vez que se usa el enlace
+// El superbloque lo debe de serializar quien llama
+func (sb *SuperBlock) Symlink(path string, destino string, parentsDir []string, name string, uid int32, gid int32) error {
+	folderIndex, err := sb.resolveLinkFolder(path, parentsDir, name, uid, gid)
+	if err != nil {
+		return err
+	}
+	err = sb.ValidateName(name)
+	if err != nil {
+		return err
+	}
+
+	linkIndex, err := sb.newDataInode(path, '2', destino, [3]byte{'7', '7', '7'}, uid, gid)
+	if err != nil {
+		return err
+	}
+
+	// Si no hay espacio en la carpeta el enlace se libera
+	err = sb.addFolderEntry(path, folderIndex, name, linkIndex)
+	if err != nil {
+		return sb.releaseUnlinked(path, linkIndex, err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package structures
+
+import "testing"
+
+// Retorna el inodo de la ruta, falla la prueba si no existe
+func mustResolve(t *testing.T, sb *SuperBlock, path string, components ...string) int32 {
+	t.Helper()
+
+	inodeIndex, err := sb.ResolvePath(path, components, RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return inodeIndex
+}
+
+func TestHardLinksInSameFolder(t *testing.T) {
+	sb, path := newTestFilesystem(t, 16, 64)
+
+	err := sb.CreateFile(false, path, nil, "a.txt", "contenido", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.HardLink(path, []string{"a.txt"}, nil, "enlace_con_nombre_largo", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := mustResolve(t, sb, path, "a.txt")
+	if enlace := mustResolve(t, sb, path, "enlace_con_nombre_largo"); enlace != original {
+		t.Fatalf("el enlace apunta al inodo %d, se esperaba %d", enlace, original)
+	}
+	inode := &Inode{}
+	err = inode.Deserialize(path, sb.InodeOffset(original))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inode.I_links != 2 {
+		t.Errorf("I_links %d, se esperaba 2", inode.I_links)
+	}
+	assertConsistent(t, sb, path)
+
+	// Renombrar el enlace no cambia la otra entrada del mismo inodo
+	err = sb.RenamePath(path, nil, "enlace_con_nombre_largo", "b.txt", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mustResolve(t, sb, path, "a.txt") != original || mustResolve(t, sb, path, "b.txt") != original {
+		t.Errorf("a.txt y b.txt deben de apuntar al inodo %d", original)
+	}
+
+	// Al eliminar una entrada el contenido sigue en la otra
+	err = sb.RemovePath(path, nil, "a.txt", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contenido, err := sb.GetFileContent(path, nil, "b.txt", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contenido != "contenido" {
+		t.Errorf("contenido %q, se esperaba %q", contenido, "contenido")
+	}
+	err = inode.Deserialize(path, sb.InodeOffset(original))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inode.I_links != 1 {
+		t.Errorf("I_links %d, se esperaba 1", inode.I_links)
+	}
+	assertConsistent(t, sb, path)
+
+	// Con la última entrada se libera el inodo
+	libres := sb.S_free_inodes_count
+	err = sb.RemovePath(path, nil, "b.txt", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sb.S_free_inodes_count != libres+1 {
+		t.Errorf("inodos libres %d, se esperaba %d", sb.S_free_inodes_count, libres+1)
+	}
+	assertConsistent(t, sb, path)
+}
+
+func TestHardLinkRejectsFolders(t *testing.T) {
+	sb, path := newTestFilesystem(t, 16, 64)
+
+	err := sb.CreateFolder(false, path, nil, "docs", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.HardLink(path, []string{"docs"}, nil, "otra", RootUID, RootUID)
+	if err == nil {
+		t.Fatal("se esperaba un error al enlazar una carpeta")
+	}
+}
+
+func TestSymlinkFollowsTarget(t *testing.T) {
+	sb, path := newTestFilesystem(t, 16, 64)
+
+	err := sb.CreateFolder(true, path, []string{"home"}, "docs", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.Symlink(path, "/home/docs", nil, "atajo", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.CreateFile(false, path, []string{"atajo"}, "nota.txt", "hola", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contenido, err := sb.GetFileContent(path, []string{"home", "docs"}, "nota.txt", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contenido != "hola" {
+		t.Errorf("contenido %q, se esperaba %q", contenido, "hola")
+	}
+	assertConsistent(t, sb, path)
+}
@@ -5,7 +5,8 @@ import (
 	"encoding/binary" // Paquete para codificación y decodificación de datos binarios
 	"errors"
 	"fmt" // Paquete para formateo de E/S
-	"os"  // Paquete para funciones del sistema operativo
+	"io"
+	"os" // Paquete para funciones del sistema operativo
 	"strings"
 	"time"
 )
@@ -51,7 +52,7 @@ func (mbr *MBR) DeserializeMBR(path string) error {
 
 	// Leer solo la cantidad de bytes que corresponden al tamaño de la estructura MBR
 	buffer := make([]byte, mbrSize)
-	_, err = file.Read(buffer)
+	_, err = io.ReadFull(file, buffer)
 	if err != nil {
 		return err
 	}
@@ -67,19 +68,16 @@ func (mbr *MBR) DeserializeMBR(path string) error {
 }
 
 // Método para obtener la primera partición disponible
-func (mbr *MBR) GetFirstAvailablePartition() (*PARTITION, int, int) {
-	// Calcular el offset para el start de la partición
-	offset := binary.Size(mbr) // Tamaño del MBR en bytes
-
+// El inicio se elige entre los espacios libres del disco según el ajuste del disco (Mbr_disk_fit)
+// Retorna la partición, el inicio (-1 si no hay espacio suficiente) y el índice (-1 si no hay slots libres)
+func (mbr *MBR) GetFirstAvailablePartition(size int) (*PARTITION, int, int) {
 	// Recorrer las particiones del MBR
 	for i := 0; i < len(mbr.Mbr_partitions); i++ {
 		// Si el start de la partición es -1, entonces está disponible
-		if mbr.Mbr_partitions[i].Part_start == -1 && mbr.Mbr_size >= int32(offset) {
-			// Devolver la partición, el offset y el índice
-			return &mbr.Mbr_partitions[i], offset, i
-		} else {
-			// Calcular el nuevo offset para la siguiente partición, es decir, sumar el tamaño de la partición
-			offset += int(mbr.Mbr_partitions[i].Part_size)
+		if mbr.Mbr_partitions[i].Part_start == -1 {
+			start := SelectFreeSpace(mbr.GetFreeSpaces(), int32(size), mbr.Mbr_disk_fit[0])
+			// Devolver la partición, el inicio y el índice
+			return &mbr.Mbr_partitions[i], int(start), i
 		}
 	}
 	return nil, -1, -1
@@ -121,6 +119,22 @@ func (mbr *MBR) GetPartitionByName(name string) (*PARTITION, int) {
 	return nil, -1
 }
 
+// Método para obtener una partición por nombre sin importar si está montada
+func (mbr *MBR) GetPartitionIndexByName(name string) (*PARTITION, int) {
+	inputName := strings.Trim(name, "\x00 ")
+	for i := 0; i < len(mbr.Mbr_partitions); i++ {
+		// Los slots libres no se toman en cuenta
+		if mbr.Mbr_partitions[i].Part_start == -1 {
+			continue
+		}
+		partitionName := strings.Trim(string(mbr.Mbr_partitions[i].Part_name[:]), "\x00 ")
+		if strings.EqualFold(partitionName, inputName) {
+			return &mbr.Mbr_partitions[i], i
+		}
+	}
+	return nil, -1
+}
+
 // Método para imprimir los valores del MBR
 func (mbr *MBR) PrintMBR() {
 	// Convertir Mbr_creation_date a time.Time
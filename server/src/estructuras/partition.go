@@ -1,6 +1,9 @@
 package structures
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type PARTITION struct {
 	Part_status      [1]byte  // Estado de la partición
@@ -79,3 +82,31 @@ func (p *PARTITION) MountPartition(correlative int, id string) error {
 
 	return nil
 }
+
+// Obtiene el nombre de la partición sin caracteres nulos
+func (p *PARTITION) GetName() string {
+	return strings.Trim(string(p.Part_name[:]), "\x00 ")
+}
+
+// Desmontar la partición, vuelve al estado de creada
+func (p *PARTITION) UnmountPartition() {
+	// El valor '0' indica que la partición esta creada pero no montada
+	p.Part_status[0] = '0'
+
+	// Se limpia el correlativo y el ID asignados en el mount
+	p.Part_correlative = 0
+	p.Part_id = [4]byte{'N'}
+}
+
+// Limpia la partición dejando el slot del MBR como disponible
+func (p *PARTITION) ResetPartition() {
+	// Se dejan los mismos valores con los que mkdisk inicializa los slots
+	p.Part_status = [1]byte{'N'}
+	p.Part_type = [1]byte{'N'}
+	p.Part_fit = [1]byte{'N'}
+	p.Part_start = -1
+	p.Part_size = -1
+	p.Part_name = [16]byte{'N'}
+	p.Part_correlative = -1
+	p.Part_id = [4]byte{'N'}
+}
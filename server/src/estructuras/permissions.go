@@ -0,0 +1,88 @@
+package structures
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ChmodPath cambia los permisos UGO del archivo o carpeta en la ruta indicada
+// Solo el root o el propietario pueden cambiarlos, con recursivo tambien se cambian los elementos
+// de la carpeta que pertenecen al usuario
+func (sb *SuperBlock) ChmodPath(path string, components []string, perm [3]byte, recursivo bool, uid int32, gid int32) error {
+	for _, digito := range perm {
+		if digito < '0' || digito > '7' {
+			return errors.New("error los permisos deben de ser tres digitos entre 0 y 7")
+		}
+	}
+
+	return sb.changeInodes(path, components, recursivo, uid, gid, func(inode *Inode) {
+		inode.I_perm = perm
+	})
+}
+
+// ChownPath cambia el propietario (y su grupo) del archivo o carpeta en la ruta indicada
+// Solo el root o el propietario actual pueden cambiarlo, con recursivo tambien se cambian los elementos
+// de la carpeta que pertenecen al usuario
+func (sb *SuperBlock) ChownPath(path string, components []string, newUid int32, newGid int32, recursivo bool, uid int32, gid int32) error {
+	return sb.changeInodes(path, components, recursivo, uid, gid, func(inode *Inode) {
+		inode.I_uid = newUid
+		inode.I_gid = newGid
+	})
+}
+
+// Aplica el cambio al inodo de la ruta y, si es recursivo, a los descendientes que pertenecen al usuario
+func (sb *SuperBlock) changeInodes(path string, components []string, recursivo bool, uid int32, gid int32, cambio func(inode *Inode)) error {
+	inodeIndex, err := sb.ResolvePath(path, components, uid, gid)
+	if err != nil {
+		return err
+	}
+
+	inode := &Inode{}
+	err = inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		return err
+	}
+	if uid != RootUID && inode.I_uid != uid {
+		nombre := "/"
+		if len(components) > 0 {
+			nombre = components[len(components)-1]
+		}
+		return fmt.Errorf("error solo el root o el propietario pueden modificar %s", nombre)
+	}
+
+	return sb.changeInode(path, inodeIndex, inode, recursivo, uid, cambio)
+}
+
+// Aplica el cambio al inodo y recorre sus hijos si es una carpeta y el cambio es recursivo
+func (sb *SuperBlock) changeInode(path string, inodeIndex int32, inode *Inode, recursivo bool, uid int32, cambio func(inode *Inode)) error {
+	cambio(inode)
+	inode.touchChange()
+	err := inode.Serialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		return err
+	}
+	if !recursivo || inode.I_type[0] != '0' {
+		return nil
+	}
+
+	entradas, err := sb.GetFolderEntries(path, inodeIndex)
+	if err != nil {
+		return err
+	}
+	for _, content := range entradas {
+		hijo := &Inode{}
+		err := hijo.Deserialize(path, sb.InodeOffset(content.B_inodo))
+		if err != nil {
+			return err
+		}
+		// Los elementos de otros usuarios se omiten
+		if uid != RootUID && hijo.I_uid != uid {
+			continue
+		}
+		err = sb.changeInode(path, content.B_inodo, hijo, recursivo, uid, cambio)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package structures
+
+import "testing"
+
+func TestHasPermission(t *testing.T) {
+	// Propietario 2, grupo 3, permisos 750
+	inode := &Inode{I_uid: 2, I_gid: 3, I_perm: [3]byte{'7', '5', '0'}}
+	pruebas := []struct {
+		uid, gid int32
+		permiso  byte
+		want     bool
+	}{
+		{2, 3, PermWrite, true},
+		{2, 9, PermExec, true},
+		{4, 3, PermRead, true},
+		{4, 3, PermWrite, false},
+		{4, 3, PermExec, true},
+		{5, 5, PermRead, false},
+		{RootUID, RootUID, PermWrite, true},
+	}
+	for _, prueba := range pruebas {
+		if got := inode.HasPermission(prueba.uid, prueba.gid, prueba.permiso); got != prueba.want {
+			t.Errorf("uid %d gid %d permiso %d: %v, se esperaba %v", prueba.uid, prueba.gid, prueba.permiso, got, prueba.want)
+		}
+	}
+}
+
+func TestResolvePathRequiresExec(t *testing.T) {
+	sb, path := newTestFilesystem(t, 32, 64)
+
+	usuario := int32(2)
+	err := sb.CreateFolder(true, path, []string{"priv"}, "docs", usuario, usuario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.ChmodPath(path, []string{"priv"}, [3]byte{'7', '0', '0'}, false, usuario, usuario)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Solo el propietario puede recorrer /priv, aunque /priv/docs sea 775
+	_, err = sb.ResolvePath(path, []string{"priv", "docs"}, usuario, usuario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = sb.ResolvePath(path, []string{"priv", "docs"}, 4, 4)
+	if err == nil {
+		t.Fatal("se esperaba un error sin permiso de ejecución sobre /priv")
+	}
+	err = sb.CreateFile(false, path, []string{"priv", "docs"}, "a.txt", "", 4, 4)
+	if err == nil {
+		t.Fatal("se esperaba un error al crear dentro de /priv sin permiso de ejecución")
+	}
+
+	// Un enlace simbolico no evita la validación
+	err = sb.Symlink(path, "/priv/docs", nil, "atajo", 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = sb.ResolvePath(path, []string{"atajo"}, 4, 4)
+	if err == nil {
+		t.Fatal("se esperaba un error al seguir el enlace sin permiso de ejecución")
+	}
+}
+
+func TestChmodOnlyOwnerAndRecursive(t *testing.T) {
+	sb, path := newTestFilesystem(t, 32, 64)
+
+	usuario := int32(2)
+	err := sb.CreateFolder(false, path, nil, "docs", usuario, usuario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.CreateFile(false, path, []string{"docs"}, "propio.txt", "", usuario, usuario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.CreateFile(false, path, []string{"docs"}, "root.txt", "", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sb.ChmodPath(path, []string{"docs"}, [3]byte{'7', '7', '7'}, false, 4, 4)
+	if err == nil {
+		t.Fatal("se esperaba un error al cambiar los permisos de otro usuario")
+	}
+	err = sb.ChmodPath(path, []string{"docs"}, [3]byte{'7', '8', '0'}, false, usuario, usuario)
+	if err == nil {
+		t.Fatal("se esperaba un error con permisos invalidos")
+	}
+
+	// Con recursivo solo cambian los elementos del usuario
+	err = sb.ChmodPath(path, []string{"docs"}, [3]byte{'7', '0', '0'}, true, usuario, usuario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][3]byte{
+		"":           {'7', '0', '0'},
+		"propio.txt": {'7', '0', '0'},
+		"root.txt":   {'6', '6', '4'},
+	}
+	for nombre, perm := range want {
+		inode := &Inode{}
+		err := inode.Deserialize(path, sb.InodeOffset(mustResolve(t, sb, path, "docs", nombre)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if inode.I_perm != perm {
+			t.Errorf("/docs/%s tiene %s, se esperaba %s", nombre, inode.I_perm[:], perm[:])
+		}
+	}
+}
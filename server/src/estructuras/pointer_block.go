@@ -4,12 +4,23 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
 )
 
 type PointerBlock struct {
-	P_pointers [16]int32 // 16 * 4 = 64 bytes
-	// Total: 64 bytes
+	P_pointers []int32 // S_block_size / 4 apuntadores
+}
+
+// NewPointerBlock crea un bloque de apuntadores del tamaño de bloque indicado con todos en -1
+func NewPointerBlock(blockSize int32) *PointerBlock {
+	fb := &PointerBlock{P_pointers: make([]int32, blockSize/4)}
+	for i := range fb.P_pointers {
+		fb.P_pointers[i] = -1
+	}
+	return fb
 }
 
 // Serialize escribe la estructura FileBlock en un archivo binario en la posición especificada
@@ -26,8 +37,8 @@ func (fb *PointerBlock) Serialize(path string, offset int64) error {
 		return err
 	}
 
-	// Serializar la estructura PointerBlock directamente en el archivo
-	err = binary.Write(file, binary.LittleEndian, fb)
+	// Serializar los apuntadores directamente en el archivo
+	err = binary.Write(file, binary.LittleEndian, fb.P_pointers)
 	if err != nil {
 		return err
 	}
@@ -49,22 +60,22 @@ func (fb *PointerBlock) Deserialize(path string, offset int64) error {
 		return err
 	}
 
-	// Obtener el tamaño de la estructura FileBlock
-	fbSize := binary.Size(fb)
+	// Obtener el tamaño del bloque, los apuntadores se crean con NewPointerBlock
+	fbSize := binary.Size(fb.P_pointers)
 	if fbSize <= 0 {
 		return fmt.Errorf("invalid PointerBlock size: %d", fbSize)
 	}
 
 	// Leer solo la cantidad de bytes que corresponden al tamaño de la estructura FileBlock
 	buffer := make([]byte, fbSize)
-	_, err = file.Read(buffer)
+	_, err = io.ReadFull(file, buffer)
 	if err != nil {
 		return err
 	}
 
 	// Deserializar los bytes leídos en la estructura FileBlock
 	reader := bytes.NewReader(buffer)
-	err = binary.Read(reader, binary.LittleEndian, fb)
+	err = binary.Read(reader, binary.LittleEndian, fb.P_pointers)
 	if err != nil {
 		return err
 	}
@@ -76,3 +87,17 @@ func (fb *PointerBlock) Deserialize(path string, offset int64) error {
 func (fb *PointerBlock) Print() {
 	fmt.Printf("%v\n", fb.P_pointers) // Imprime como un array de enteros
 }
+
+func (fb *PointerBlock) ObtenerDot() string {
+	// Los apuntadores se muestran separados por comas, -1 indica que esta libre
+	apuntadores := make([]string, len(fb.P_pointers))
+	for i, pointer := range fb.P_pointers {
+		apuntadores[i] = strconv.Itoa(int(pointer))
+	}
+
+	cadena := fmt.Sprintf(`
+		<tr><td colspan="2" bgcolor="#0000FF"><font color="white"> BLOQUE APUNTADORES </font></td></tr>
+		<tr><td colspan="2"> %s </td></tr>`, strings.Join(apuntadores, ", "))
+
+	return cadena
+}
@@ -0,0 +1,101 @@
+package structures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RemovePath elimina el archivo o la carpeta (con todo su contenido) que esta en la ruta indicada
+// Si el usuario no tiene permiso de escritura sobre la carpeta padre o sobre algun elemento no se elimina nada
+// El superbloque lo debe de serializar quien llama
+func (sb *SuperBlock) RemovePath(path string, parentsDir []string, name string, uid int32, gid int32) error {
+	if name == "" {
+		return errors.New("error no se puede eliminar la carpeta raíz")
+	}
+
+	// Buscar la carpeta padre y el inodo a eliminar
+	parentIndex, err := sb.ResolvePath(path, parentsDir, uid, gid)
+	if err != nil {
+		return err
+	}
+	// Si es un enlace simbolico se elimina el enlace y no lo que apunta
+	childIndex, err := sb.lookupEntry(path, parentIndex, name, uid, gid)
+	if err != nil {
+		return err
+	}
+	if childIndex == -1 {
+		return fmt.Errorf("error la ruta %s no existe", name)
+	}
+	// La entrada se quita de la carpeta padre, por eso tambien se necesita escribir en ella
+	err = sb.checkPermission(path, parentIndex, parentName(parentsDir), uid, gid, PermWrite)
+	if err != nil {
+		return err
+	}
+
+	// Primero se validan los permisos de todo el contenido, asi no queda eliminado a medias
+	var inodos []int32
+	ruta := "/" + strings.Join(append(parentsDir, name), "/")
+	err = sb.collectRemovable(path, childIndex, ruta, uid, gid, &inodos)
+	if err != nil {
+		return err
+	}
+
+	// Liberar los bloques y los inodos, un inodo con enlaces duros solo se libera al quitar su última entrada
+	for _, inodeIndex := range inodos {
+		inode := &Inode{}
+		err := inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+		if err != nil {
+			return err
+		}
+		inode.I_links--
+		if inode.I_links > 0 {
+			inode.touchChange()
+			err = inode.Serialize(path, sb.InodeOffset(inodeIndex))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		err = sb.FreeInodeBlocks(path, inode)
+		if err != nil {
+			return err
+		}
+		err = sb.FreeInode(path, inodeIndex)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Quitar la entrada de la carpeta padre
+	return sb.removeFolderEntry(path, parentIndex, name, childIndex)
+}
+
+// Agrega a inodos el inodo y todos sus descendientes, validando el permiso de escritura de cada uno
+func (sb *SuperBlock) collectRemovable(path string, inodeIndex int32, ruta string, uid int32, gid int32, inodos *[]int32) error {
+	inode := &Inode{}
+	err := inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		return err
+	}
+	if !inode.HasPermission(uid, gid, PermWrite) {
+		return fmt.Errorf("error no tiene permiso de escritura sobre %s", ruta)
+	}
+	*inodos = append(*inodos, inodeIndex)
+
+	if inode.I_type[0] != '0' {
+		return nil
+	}
+
+	entradas, err := sb.GetFolderEntries(path, inodeIndex)
+	if err != nil {
+		return err
+	}
+	for _, content := range entradas {
+		err := sb.collectRemovable(path, content.B_inodo, ruta+"/"+content.GetName(), uid, gid, inodos)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
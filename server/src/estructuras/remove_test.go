@@ -0,0 +1,105 @@
+package structures
+
+import "testing"
+
+func TestRemoveAbortsWithoutPermission(t *testing.T) {
+	sb, path := newTestFilesystem(t, 32, 64)
+
+	// El usuario 2 es dueño de todo menos de /docs/sub/root.txt
+	usuario := int32(2)
+	err := sb.CreateFolder(true, path, []string{"docs"}, "sub", usuario, usuario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.CreateFile(false, path, []string{"docs"}, "a.txt", "a", usuario, usuario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.CreateFile(false, path, []string{"docs", "sub"}, "root.txt", "root", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inodos, bloques := sb.S_free_inodes_count, sb.S_free_blocks_count
+
+	err = sb.RemovePath(path, nil, "docs", usuario, usuario)
+	if err == nil {
+		t.Fatal("se esperaba un error sin permiso de escritura sobre root.txt")
+	}
+
+	// No se elimina nada
+	if sb.S_free_inodes_count != inodos || sb.S_free_blocks_count != bloques {
+		t.Errorf("libres %d/%d, se esperaba %d/%d", sb.S_free_inodes_count, sb.S_free_blocks_count, inodos, bloques)
+	}
+	for _, ruta := range [][]string{{"docs"}, {"docs", "a.txt"}, {"docs", "sub"}, {"docs", "sub", "root.txt"}} {
+		_, err := sb.ResolvePath(path, ruta, RootUID, RootUID)
+		if err != nil {
+			t.Errorf("%v: %v", ruta, err)
+		}
+	}
+	assertConsistent(t, sb, path)
+}
+
+func TestRemoveRequiresParentWrite(t *testing.T) {
+	sb, path := newTestFilesystem(t, 32, 64)
+
+	usuario := int32(2)
+	err := sb.CreateFolder(false, path, nil, "ro", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.CreateFile(false, path, []string{"ro"}, "propio.txt", "x", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.ChownPath(path, []string{"ro", "propio.txt"}, usuario, usuario, false, RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// El archivo es del usuario pero la carpeta no le permite escribir
+	err = sb.RemovePath(path, []string{"ro"}, "propio.txt", usuario, usuario)
+	if err == nil {
+		t.Fatal("se esperaba un error sin permiso de escritura sobre la carpeta")
+	}
+	mustResolve(t, sb, path, "ro", "propio.txt")
+
+	err = sb.ChmodPath(path, []string{"ro"}, [3]byte{'7', '7', '7'}, false, RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inodos := sb.S_free_inodes_count
+	err = sb.RemovePath(path, []string{"ro"}, "propio.txt", usuario, usuario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sb.S_free_inodes_count != inodos+1 {
+		t.Errorf("inodos libres %d, se esperaba %d", sb.S_free_inodes_count, inodos+1)
+	}
+	assertConsistent(t, sb, path)
+}
+
+func TestRemoveFreesWholeTree(t *testing.T) {
+	sb, path := newTestFilesystem(t, 32, 64)
+	inodos, bloques := sb.S_free_inodes_count, sb.S_free_blocks_count
+
+	err := sb.CreateFolder(true, path, []string{"a", "b"}, "c", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, nombre := range []string{"uno.txt", "dos.txt", "tres.txt", "cuatro.txt", "cinco.txt"} {
+		err := sb.CreateFile(false, path, []string{"a", "b"}, nombre, nombre, RootUID, RootUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = sb.RemovePath(path, nil, "a", RootUID, RootUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// La raíz conserva el bloque de su entrada, los demas bloques e inodos quedan libres
+	if sb.S_free_inodes_count != inodos || sb.S_free_blocks_count != bloques {
+		t.Errorf("libres %d/%d, se esperaba %d/%d", sb.S_free_inodes_count, sb.S_free_blocks_count, inodos, bloques)
+	}
+	assertConsistent(t, sb, path)
+}
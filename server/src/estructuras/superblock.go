@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -16,8 +17,8 @@ type SuperBlock struct {
 	S_blocks_count      int32
 	S_free_inodes_count int32
 	S_free_blocks_count int32
-	S_mtime             float32
-	S_umtime            float32
+	S_mtime             int64 // Segundos Unix del último montaje
+	S_umtime            int64 // Segundos Unix del último desmontaje
 	S_mnt_count         int32
 	S_magic             int32
 	S_inode_size        int32
@@ -28,7 +29,8 @@ type SuperBlock struct {
 	S_bm_block_start    int32
 	S_inode_start       int32
 	S_block_start       int32
-	// Total: 68 bytes
+	S_version           int32 // Versión del formato en disco, ver FormatVersion
+	// Total: 84 bytes
 }
 
 // Serialize escribe la estructura SuperBlock en un archivo binario en la posición especificada
@@ -76,7 +78,7 @@ func (sb *SuperBlock) Deserialize(path string, offset int64) error {
 
 	// Leer solo la cantidad de bytes que corresponden al tamaño de la estructura SuperBlock
 	buffer := make([]byte, sbSize)
-	_, err = file.Read(buffer)
+	_, err = io.ReadFull(file, buffer)
 	if err != nil {
 		return err
 	}
@@ -88,15 +90,20 @@ func (sb *SuperBlock) Deserialize(path string, offset int64) error {
 		return err
 	}
 
+	// Un sistema de archivos del formato anterior se debe de migrar antes de usarlo
+	if sb.S_magic != 0xEF53 && isLegacyFormat(buffer) {
+		return ErrLegacyFormat
+	}
+
 	return nil
 }
 
 // PrintSuperBlock imprime los valores de la estructura SuperBlock
 func (sb *SuperBlock) Print() {
 	// Convertir el tiempo de montaje a una fecha
-	mountTime := time.Unix(int64(sb.S_mtime), 0)
+	mountTime := time.Unix(sb.S_mtime, 0)
 	// Convertir el tiempo de desmontaje a una fecha
-	unmountTime := time.Unix(int64(sb.S_umtime), 0)
+	unmountTime := time.Unix(sb.S_umtime, 0)
 
 	fmt.Printf("Filesystem Type: %d\n", sb.S_filesystem_type)
 	fmt.Printf("Inodes Count: %d\n", sb.S_inodes_count)
@@ -115,14 +122,15 @@ func (sb *SuperBlock) Print() {
 	fmt.Printf("Bitmap Block Start: %d\n", sb.S_bm_block_start)
 	fmt.Printf("Inode Start: %d\n", sb.S_inode_start)
 	fmt.Printf("Block Start: %d\n", sb.S_block_start)
+	fmt.Printf("Version: %d\n", sb.S_version)
 }
 
 // Esta funcion retorna el codigo de dot del superbloque
 func (sb *SuperBlock) ObtenerDot() string {
 	// Convertir el tiempo de montaje a una fecha
-	mountTime := time.Unix(int64(sb.S_mtime), 0)
+	mountTime := time.Unix(sb.S_mtime, 0)
 	// Convertir el tiempo de desmontaje a una fecha
-	unmountTime := time.Unix(int64(sb.S_umtime), 0)
+	unmountTime := time.Unix(sb.S_umtime, 0)
 
 	// Agregar los bloques indirectos a la tabla
 	dotContent := fmt.Sprintf(`tabla [label=<
@@ -145,11 +153,12 @@ func (sb *SuperBlock) ObtenerDot() string {
 			<tr><td>S_bm_block_start</td><td>%d</td></tr>
 			<tr><td bgcolor="#32CD32"><font color="white">S_inode_start</font></td><td bgcolor="#32CD32"><font color="white">%d</font></td></tr>
 			<tr><td>S_block_start</td><td>%d</td></tr>
+			<tr><td bgcolor="#32CD32"><font color="white">S_version</font></td><td bgcolor="#32CD32"><font color="white">%d</font></td></tr>
 		</table>>];
 		`, sb.S_filesystem_type, sb.S_inodes_count, sb.S_blocks_count,
 		sb.S_free_blocks_count, sb.S_free_inodes_count, mountTime.Format(time.RFC3339),
 		unmountTime.Format(time.RFC3339), sb.S_mnt_count, sb.S_magic, sb.S_inode_size, sb.S_block_size,
-		sb.S_first_ino, sb.S_first_blo, sb.S_bm_inode_start, sb.S_bm_block_start, sb.S_inode_start, sb.S_block_start)
+		sb.S_first_ino, sb.S_first_blo, sb.S_bm_inode_start, sb.S_bm_block_start, sb.S_inode_start, sb.S_block_start, sb.S_version)
 
 	return dotContent
 }
@@ -158,8 +167,13 @@ func (sb *SuperBlock) ObtenerDot() string {
 func (sb *SuperBlock) PrintInodes(path string) error {
 	// Imprimir inodos
 	fmt.Println("\nInodos\n----------------")
+	// Obtener los inodos usados del bitmap
+	usados, err := sb.GetUsedInodes(path)
+	if err != nil {
+		return err
+	}
 	// Iterar sobre cada inodo
-	for i := int32(0); i < sb.S_inodes_count; i++ {
+	for _, i := range usados {
 		inode := &Inode{}
 		// Deserializar el inodo
 		err := inode.Deserialize(path, int64(sb.S_inode_start+(i*sb.S_inode_size)))
@@ -178,25 +192,30 @@ func (sb *SuperBlock) PrintInodes(path string) error {
 func (sb *SuperBlock) PrintBlocks(path string) error {
 	// Imprimir bloques
 	fmt.Println("\nBloques\n----------------")
+	// Obtener los inodos usados del bitmap
+	usados, err := sb.GetUsedInodes(path)
+	if err != nil {
+		return err
+	}
 	// Iterar sobre cada inodo
-	for i := int32(0); i < sb.S_inodes_count; i++ {
+	for _, i := range usados {
 		inode := &Inode{}
 		// Deserializar el inodo
 		err := inode.Deserialize(path, int64(sb.S_inode_start+(i*sb.S_inode_size)))
 		if err != nil {
 			return err
 		}
-		// Iterar sobre cada bloque del inodo (apuntadores)
-		for _, blockIndex := range inode.I_block {
-			// Si el bloque no existe, salir
-			if blockIndex == -1 {
-				break
-			}
+		// Bloques de datos del inodo, incluyendo los de los bloques indirectos
+		bloques, err := sb.GetInodeBlocks(path, inode)
+		if err != nil {
+			return err
+		}
+		for _, blockIndex := range bloques {
 			// Si el inodo es de tipo carpeta
 			if inode.I_type[0] == '0' {
-				block := &FolderBlock{}
+				block := NewFolderBlock(sb.S_block_size)
 				// Deserializar el bloque
-				err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size))) // 64 porque es el tamaño de un bloque
+				err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size))) // S_block_size es el tamaño de un bloque
 				if err != nil {
 					return err
 				}
@@ -206,10 +225,10 @@ func (sb *SuperBlock) PrintBlocks(path string) error {
 				continue
 
 				// Si el inodo es de tipo archivo
-			} else if inode.I_type[0] == '1' {
-				block := &FileBlock{}
+			} else if inode.I_type[0] == '1' || inode.I_type[0] == '2' {
+				block := NewFileBlock(sb.S_block_size)
 				// Deserializar el bloque
-				err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size))) // 64 porque es el tamaño de un bloque
+				err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size))) // S_block_size es el tamaño de un bloque
 				if err != nil {
 					return err
 				}
@@ -226,19 +245,24 @@ func (sb *SuperBlock) PrintBlocks(path string) error {
 }
 
 // CreateFolder crea una carpeta en el sistema de archivos
-func (sb *SuperBlock) CreateFolder(crear_padres bool, path string, parentsDir []string, destDir string) error {
+// El usuario debe de tener permiso de escritura en cada carpeta donde se crea una nueva
+func (sb *SuperBlock) CreateFolder(crear_padres bool, path string, parentsDir []string, destDir string, uid int32, gid int32) error {
 	// Si parentsDir está vacío, solo trabajar con el primer inodo que sería el raíz "/"
 	if len(parentsDir) == 0 {
 		// enviamos a buscar el directorio a crear, para saber si existe
-		next_inode, err := sb.Encontrar_Directorio(path, 0, destDir)
+		next_inode, err := sb.Encontrar_Directorio(path, 0, destDir, uid, gid)
 		// si hay un error, lo devolvemos
 		if err != nil {
 			return err
 		}
 		// si el valor de la variable es un -1, significa que el directorio no existe, por ende, hay que crearlo
 		if next_inode == int32(-1) {
+			err := sb.checkPermission(path, 0, "/", uid, gid, PermWrite)
+			if err != nil {
+				return err
+			}
 			//Aca se genera el indo y el fileblock
-			err := sb.createFolderInInode(path, 0, destDir)
+			_, err = sb.createFolderInInode(path, 0, destDir, uid, gid)
 
 			if err != nil {
 				return err
@@ -251,11 +275,12 @@ func (sb *SuperBlock) CreateFolder(crear_padres bool, path string, parentsDir []
 
 	// Iterar sobre cada inodo ya que se necesita buscar el inodo padre
 	Posicion := int32(0)
+	nombrePadre := "/"
 	//Se le agrega el ultimo, que es el final
 	parentsDir = append(parentsDir, destDir)
 	for i := 0; i < len(parentsDir); i++ {
 		// enviamos a buscar el directorio en la posicion i, para saber si existe
-		next_inode, err := sb.Encontrar_Directorio(path, Posicion, parentsDir[i])
+		next_inode, err := sb.Encontrar_Directorio(path, Posicion, parentsDir[i], uid, gid)
 		// si hay un error, lo devolvemos
 		if err != nil {
 			return err
@@ -266,16 +291,18 @@ func (sb *SuperBlock) CreateFolder(crear_padres bool, path string, parentsDir []
 		// si el valor de la variable es un -1, significa que el directorio no existe, por ende, hay que crearlo
 		if next_inode == int32(-1) {
 			if crear_padres {
-				err := sb.createFolderInInode(path, Posicion, parentsDir[i])
+				err := sb.checkPermission(path, Posicion, nombrePadre, uid, gid, PermWrite)
+				if err != nil {
+					return err
+				}
+				// Continuamos desde el inodo de la carpeta recien creada
+				nuevo, err := sb.createFolderInInode(path, Posicion, parentsDir[i], uid, gid)
 
 				if err != nil {
 					return err
 				}
 
-				/*
-					ya que creamos una carpeta (inode) y es el inmediato siguiente al que teniamos, simplemente sumamos 1 a Inode_destino y mantenemos la continuidad
-				*/
-				Posicion = sb.S_inodes_count - 1
+				Posicion = nuevo
 
 			} else {
 				return errors.New("error los directorios padres de la ruta no existe")
@@ -286,6 +313,7 @@ func (sb *SuperBlock) CreateFolder(crear_padres bool, path string, parentsDir []
 			*/
 			Posicion = next_inode
 		}
+		nombrePadre = parentsDir[i]
 	}
 	//sb.Print()
 
@@ -293,19 +321,24 @@ func (sb *SuperBlock) CreateFolder(crear_padres bool, path string, parentsDir []
 }
 
 // CreateFile crea una archivo en el sistema de archivos
-func (sb *SuperBlock) CreateFile(crear_padres bool, path string, parentsDir []string, nombreArchivo string, contenido string) error {
+// El usuario debe de tener permiso de escritura en cada carpeta donde se crea una nueva y en la carpeta del archivo
+func (sb *SuperBlock) CreateFile(crear_padres bool, path string, parentsDir []string, nombreArchivo string, contenido string, uid int32, gid int32) error {
 	// Si parentsDir está vacío, solo trabajar con el primer inodo que sería el raíz "/"
 	if len(parentsDir) == 0 {
 		// enviamos a buscar el directorio a crear, para saber si existe
-		next_inode, err := sb.Encontrar_Directorio(path, 0, nombreArchivo)
+		next_inode, err := sb.Encontrar_Directorio(path, 0, nombreArchivo, uid, gid)
 		// si hay un error, lo devolvemos
 		if err != nil {
 			return err
 		}
 		// si el valor de la variable es un -1, significa que el directorio no existe, por ende, hay que crearlo
 		if next_inode == int32(-1) {
+			err := sb.checkPermission(path, 0, "/", uid, gid, PermWrite)
+			if err != nil {
+				return err
+			}
 			//Aca se genera el indo y el fileblock
-			err := sb.createFileInInode(path, 0, nombreArchivo, contenido)
+			err = sb.createFileInInode(path, 0, nombreArchivo, contenido, uid, gid)
 
 			if err != nil {
 				return err
@@ -319,11 +352,12 @@ func (sb *SuperBlock) CreateFile(crear_padres bool, path string, parentsDir []st
 
 	// Iterar sobre cada inodo ya que se necesita buscar el inodo padre
 	Posicion := int32(0)
+	nombrePadre := "/"
 	//Se le agrega el ultimo, que es el final, '1'
 	//parentsDir = append(parentsDir, nombreArchivo) //TODO: aca creo que es el problema como se le agrega el archivo
 	for i := 0; i < len(parentsDir); i++ {
 		// enviamos a buscar el directorio en la posicion i, para saber si existe
-		next_inode, err := sb.Encontrar_Directorio(path, Posicion, parentsDir[i])
+		next_inode, err := sb.Encontrar_Directorio(path, Posicion, parentsDir[i], uid, gid)
 		// si hay un error, lo devolvemos
 		if err != nil {
 			return err
@@ -332,16 +366,18 @@ func (sb *SuperBlock) CreateFile(crear_padres bool, path string, parentsDir []st
 		// si el valor de la variable es un -1, significa que el directorio no existe, por ende, hay que crearlo
 		if next_inode == int32(-1) {
 			if crear_padres {
-				err := sb.createFolderInInode(path, Posicion, parentsDir[i])
+				err := sb.checkPermission(path, Posicion, nombrePadre, uid, gid, PermWrite)
+				if err != nil {
+					return err
+				}
+				// Continuamos desde el inodo de la carpeta recien creada
+				nuevo, err := sb.createFolderInInode(path, Posicion, parentsDir[i], uid, gid)
 
 				if err != nil {
 					return err
 				}
 
-				/*
-					ya que creamos una carpeta (inode) y es el inmediato siguiente al que teniamos, simplemente sumamos 1 a Inode_destino y mantenemos la continuidad
-				*/
-				Posicion = sb.S_inodes_count - 1
+				Posicion = nuevo
 
 			} else {
 				return errors.New("error los directorios padres de la ruta para el file no existe")
@@ -352,10 +388,16 @@ func (sb *SuperBlock) CreateFile(crear_padres bool, path string, parentsDir []st
 			*/
 			Posicion = next_inode
 		}
+		nombrePadre = parentsDir[i]
+	}
+
+	err := sb.checkPermission(path, Posicion, nombrePadre, uid, gid, PermWrite)
+	if err != nil {
+		return err
 	}
 
 	// si el nombre del directorio termina con .txt entonces es un archivo
-	err := sb.createFileInInode(path, Posicion, nombreArchivo, contenido)
+	err = sb.createFileInInode(path, Posicion, nombreArchivo, contenido, uid, gid)
 
 	if err != nil {
 		return err
@@ -365,150 +407,82 @@ func (sb *SuperBlock) CreateFile(crear_padres bool, path string, parentsDir []st
 }
 
 // Esta funcion para buscar el directorio en donde se debe de crear el fileblok
-func (sb *SuperBlock) Encontrar_Directorio(path string, inodeIndex int32, destDir string) (int32, error) {
-	// Crear un nuevo inodo
-	inode := &Inode{}
-	// Deserializar el inodo
-	err := inode.Deserialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
+// Retorna -1 si la carpeta inodeIndex no tiene una entrada con el nombre destDir
+// Si la entrada es un enlace simbolico se retorna el inodo al que apunta
+func (sb *SuperBlock) Encontrar_Directorio(path string, inodeIndex int32, destDir string, uid int32, gid int32) (int32, error) {
+	saltos := 0
+	return sb.findFollowing(path, inodeIndex, destDir, &saltos, uid, gid)
+}
+
+// lookupEntry busca la entrada destDir en la carpeta inodeIndex sin seguir los enlaces simbolicos
+// Retorna -1 si la carpeta no tiene una entrada con ese nombre
+// Buscar en la carpeta requiere permiso de ejecución sobre ella
+func (sb *SuperBlock) lookupEntry(path string, inodeIndex int32, destDir string, uid int32, gid int32) (int32, error) {
+	err := sb.checkSearch(path, inodeIndex, uid, gid)
 	if err != nil {
 		return int32(-1), err
 	}
-	// Verificar si el inodo es de tipo carpeta
-	//fmt.Println(inodeIndex)
-	if inode.I_type[0] == '1' {
-		// fmt.Println(inode.I_type[0])
-		// fmt.Println(tipo[0])
-		return int32(-1), errors.New("error los directorios de la ruta es un archivo")
-	}
-
-	// Iterar sobre cada bloque del inodo (apuntadores)
-	for _, blockIndex := range inode.I_block {
-		// Si el bloque no existe, salir
-		if blockIndex == -1 {
-			break
-		}
-
-		// Crear un nuevo bloque de carpeta
-		block := &FolderBlock{}
-
-		// Deserializar el bloque
-		err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size))) // 64 porque es el tamaño de un bloque
-		if err != nil {
-			return int32(-1), err
-		}
 
-		// Iterar sobre cada contenido del bloque, desde el index 2 porque los primeros dos son . y ..
-		for indexContent := 2; indexContent < len(block.B_content); indexContent++ {
-			// Obtener el contenido del bloque
-			content := block.B_content[indexContent]
-
-			// Sí las carpetas padre no están vacías debereamos buscar la carpeta padre más cercana
-			//fmt.Println("---------ESTOY  VISITANDO--------")
-
-			// Si el contenido está vacío, salir
-			if content.B_inodo == -1 {
-				break
-			}
-
-			// Obtenemos la carpeta padre más cercana
-			// parentDir, err := utils.First(parentsDir)
-			// if err != nil {
-			// 	return err
-			// }
-
-			// Convertir B_name a string y eliminar los caracteres nulos
-			contentName := strings.Trim(string(content.B_name[:]), "\x00 ")
-			// Convertir parentDir a string y eliminar los caracteres nulos
-			parentDirName := strings.Trim(destDir, "\x00 ")
-			// fmt.Println("****************Commparacion***********************")
-			// fmt.Println(contentName)
-			// fmt.Println(parentDirName)
-			// Si el nombre del contenido coincide con el nombre de la carpeta padre
-			if strings.EqualFold(contentName, parentDirName) {
-				//fmt.Println("****************ADA***********************")
-				return int32(content.B_inodo), nil
-				//return nil
-			}
+	// Obtener las entradas de todos los bloques de la carpeta
+	entradas, err := sb.GetFolderEntries(path, inodeIndex)
+	if err != nil {
+		return int32(-1), err
+	}
 
+	// Convertir destDir a string y eliminar los caracteres nulos
+	parentDirName := strings.Trim(destDir, "\x00 ")
+	for _, content := range entradas {
+		// Si el nombre del contenido coincide con el nombre de la carpeta padre
+		if strings.EqualFold(content.GetName(), parentDirName) {
+			return content.B_inodo, nil
 		}
-
 	}
-	//fmt.Println("*************NO SE ENCONTRO***********************")
 	return int32(-1), nil
 }
 
-// CreateFolder crea una carpeta en el sistema de archivos
-func (sb *SuperBlock) GetFileContent(path string, parentsDir []string, destDir string) (string, error) {
-	// Si parentsDir está vacío, solo trabajar con el primer inodo que sería el raíz "/"
-	if len(parentsDir) == 0 {
-		return sb.getContenidoFile(path, 0, parentsDir, destDir)
+// GetFileContent obtiene el contenido del archivo que se encuentra en la ruta indicada
+// El usuario debe de tener permiso de lectura sobre el archivo
+func (sb *SuperBlock) GetFileContent(path string, parentsDir []string, destDir string, uid int32, gid int32) (string, error) {
+	// Se resuelve la ruta completa desde la raíz
+	inodeIndex, err := sb.ResolvePath(path, append(parentsDir, destDir), uid, gid)
+	if err != nil {
+		return "", err
 	}
 
-	// Iterar sobre cada inodo ya que se necesita buscar el inodo padre
-	for i := int32(0); i < sb.S_inodes_count; i++ {
-		cade, err := sb.getContenidoFile(path, i, parentsDir, destDir)
-		if err != nil {
-			return "", err
-		}
-		//Si la cadnea es distinta de cadena vasilla
-		if cade != "" {
-			return cade, nil
-		}
+	inode := &Inode{}
+	err = inode.Deserialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		return "", err
 	}
-	//sb.Print()
-
-	return "", nil
-}
-
-// Esta funcion es para el reporte del ls, el cual retorna codigo de tipo .dot
-func (sb *SuperBlock) ObtenerDotLS(path string, parentsDir []string, destDir string) (string, error) {
-	// Si parentsDir está vacío, solo trabajar con el primer inodo que sería el raíz "/"
-	if len(parentsDir) == 0 {
-		// enviamos a buscar el directorio a crear, para saber si existe
-		next_inode, err := sb.Encontrar_Directorio(path, 0, destDir)
-		// si hay un error, lo devolvemos
-		if err != nil {
-			return "", err
-		}
-		// si el valor de la variable es un -1, significa que el directorio no existe, por ende, hay que crearlo
-		if next_inode == int32(-1) {
-			//fmt.Println("Aca si")
-			//Aca se genera el indo y el fileblock
-			cadena, err := sb.obtnerDot_LS(path, 0)
-
-			if err != nil {
-				return "", err
-			}
-			return cadena, nil
-		}
-		return "", nil
+	if inode.I_type[0] != '1' {
+		return "", fmt.Errorf("error %s no es un archivo", destDir)
+	}
+	if !inode.HasPermission(uid, gid, PermRead) {
+		return "", fmt.Errorf("error no tiene permiso de lectura sobre %s", destDir)
 	}
 
-	// Iterar sobre cada inodo ya que se necesita buscar el inodo padre
-	Posicion := int32(0)
-	//Se le agrega el ultimo, que es el final, '1'
-	parentsDir = append(parentsDir, destDir) //TODO: aca creo que es el problema como se le agrega el archivo
-	for i := 0; i < len(parentsDir); i++ {
-		// enviamos a buscar el directorio en la posicion i, para saber si existe
-		next_inode, err := sb.Encontrar_Directorio(path, Posicion, parentsDir[i])
-		// si hay un error, lo devolvemos
-		if err != nil {
-			return "", err
-		}
-
-		// si el valor de la variable es un -1, significa que el directorio no existe, por ende, hay que crearlo
-		if next_inode == int32(-1) {
-			Posicion = sb.S_inodes_count - 1
-		} else {
-			Posicion = next_inode
-		}
+	// Se leen los bloques directos e indirectos del archivo
+	contenido, err := sb.ReadFileContent(path, inode)
+	if err != nil {
+		return "", err
 	}
 
-	cadena, err := sb.obtnerDot_LS(path, Posicion)
+	// Leer el contenido actualiza atime
+	inode.touchAccess()
+	err = inode.Serialize(path, sb.InodeOffset(inodeIndex))
+	if err != nil {
+		return "", err
+	}
+	return contenido, nil
+}
 
+// Esta funcion es para el reporte del ls, el cual retorna codigo de tipo .dot
+func (sb *SuperBlock) ObtenerDotLS(path string, parentsDir []string, destDir string, uid int32, gid int32) (string, error) {
+	// Se resuelve la ruta completa desde la raíz, "/" es la carpeta raíz
+	inodeIndex, err := sb.ResolvePath(path, append(parentsDir, destDir), uid, gid)
 	if err != nil {
 		return "", err
 	}
 
-	return cadena, nil
+	return sb.obtnerDot_LS(path, inodeIndex, destDir)
 }
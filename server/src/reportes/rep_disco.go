@@ -3,9 +3,11 @@ package reportes
 import (
 	structures "bakend/src/estructuras"
 	utils "bakend/src/utils"
+	"encoding/binary"
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 )
 
@@ -19,26 +21,29 @@ func ReporteDisk(mbrparticion *structures.MBR, superblock *structures.SuperBlock
 	// Obtener el nombre base del archivo sin la extensión
 	dotFileName, outputImage := utils.GetFileNames(path)
 
-	// Leer y procesar los EBRs si hay particiones extendidas
-	var ebrs []structures.EBR
-	contador := 0
+	totalDiskSize := mbrparticion.Mbr_size
+	mbrSize := int32(binary.Size(mbrparticion))
+	ebrSize := int32(binary.Size(structures.EBR{}))
+
+	// Se juntan las particiones y los espacios libres para mostrarlos en el orden en que estan en el disco
+	type segmento struct {
+		start     int32
+		partition *structures.PARTITION
+		libre     int32
+	}
+	var segmentos []segmento
 	for i := 0; i < 4; i++ {
-		if string(mbrparticion.Mbr_partitions[i].Part_type[:]) == "E" { // Partición extendida
-			ebrPosition := mbrparticion.Mbr_partitions[i].Part_start
-			for ebrPosition != -1 {
-				var tempEBR structures.EBR
-				err := tempEBR.DeserializeEBR(diskPath, int32(ebrPosition))
-				if err != nil {
-					break
-				}
-				ebrs = append(ebrs, tempEBR)
-				ebrPosition = tempEBR.Ebr_next
-				contador++
-			}
+		if mbrparticion.Mbr_partitions[i].Part_start != -1 && mbrparticion.Mbr_partitions[i].Part_size > 0 {
+			segmentos = append(segmentos, segmento{start: mbrparticion.Mbr_partitions[i].Part_start, partition: &mbrparticion.Mbr_partitions[i]})
 		}
 	}
+	for _, libre := range mbrparticion.GetFreeSpaces() {
+		segmentos = append(segmentos, segmento{start: libre.Start, libre: libre.Size})
+	}
+	sort.Slice(segmentos, func(i, j int) bool {
+		return segmentos[i].start < segmentos[j].start
+	})
 
-	contador = contador * len(ebrs)
 	// Iniciar el contenido del archivo en formato Graphviz (.dot)
 	content := "digraph G {\n"
 	content += "\tnode [shape=none];\n"
@@ -51,47 +56,68 @@ func ReporteDisk(mbrparticion *structures.MBR, superblock *structures.SuperBlock
 	// Iniciar tabla para las particiones
 	content += "\t\ttable [label=<\n\t\t\t<TABLE BORDER=\"1\" CELLBORDER=\"1\" CELLSPACING=\"0\" CELLPADDING=\"10\">\n"
 	content += "\t\t\t<TR>\n"
-	content += "\t\t\t<TD>MBR (159 bytes)</TD>\n"
+	content += fmt.Sprintf("\t\t\t<TD>MBR (%d bytes)</TD>\n", mbrSize)
 
-	// Variables para el porcentaje y espacio libre
-	totalDiskSize := mbrparticion.Mbr_size
-	var usedSpace int32 = 159 // Tamaño del MBR en bytes
-	var freeSpace int32 = totalDiskSize - usedSpace
+	for _, seg := range segmentos {
+		// Espacio libre entre particiones
+		if seg.partition == nil {
+			percentage := float64(seg.libre) / float64(totalDiskSize) * 100
+			content += fmt.Sprintf("\t\t\t<TD>Libre<br/>%.2f%% del disco</TD>\n", percentage)
+			continue
+		}
 
-	for i := 0; i < 4; i++ {
-		part := mbrparticion.Mbr_partitions[i]
-		if part.Part_size > 0 { // Si la partición tiene un tamaño valido
-			percentage := float64(part.Part_size) / float64(totalDiskSize) * 100
-			partName := strings.TrimRight(string(part.Part_name[:]), "\x00") // Limpiar el nombre de la partición
-
-			if string(part.Part_type[:]) == "P" { // Partición primaria
-				content += fmt.Sprintf("\t\t\t<TD>Primaria<br/>%s<br/>%.2f%% del disco</TD>\n", partName, percentage)
-				usedSpace += part.Part_size
-			} else if string(part.Part_type[:]) == "E" { // Partición extendida
-				content += "\t\t\t<TD>\n"
-				content += "\t\t\t\t<TABLE BORDER=\"0\" CELLBORDER=\"1\" CELLSPACING=\"0\">\n"
-				content += fmt.Sprintf("\t\t\t\t<TR><TD COLSPAN=\"%d\">Extendida</TD></TR>\n", contador)
-				//fmt.Println(contador)
-				// Leer los EBRs y agregar las particiones lógicas
-				content += "\t\t\t\t<TR>\n"
-				for _, ebr := range ebrs {
-					logicalPercentage := float64(ebr.Ebr_size) / float64(totalDiskSize) * 100
-					content += fmt.Sprintf("\t\t\t\t<TD>EBR (32 bytes)</TD>\n\t\t\t\t<TD>Lógica<br/>%.2f%% del disco</TD>\n", logicalPercentage)
-					usedSpace += ebr.Ebr_size + 32 // Añadir el tamaño de la partición lógica y el EBR
+		part := seg.partition
+		percentage := float64(part.Part_size) / float64(totalDiskSize) * 100
+		partName := strings.TrimRight(string(part.Part_name[:]), "\x00") // Limpiar el nombre de la partición
+
+		if string(part.Part_type[:]) == "E" { // Partición extendida
+			// Leer los EBRs y los espacios libres dentro de la extendida
+			cadena, err := structures.GetEBRChain(diskPath, part.Part_start)
+			if err != nil {
+				return err
+			}
+			var celdas []string
+			type logico struct {
+				start int32
+				celda string
+			}
+			var logicos []logico
+			for _, ebr := range cadena {
+				if ebr.IsEmpty() {
+					continue
 				}
-				content += "\t\t\t\t</TR>\n"
-				content += "\t\t\t\t</TABLE>\n"
-				content += "\t\t\t</TD>\n"
+				logicalPercentage := float64(ebr.Ebr_size) / float64(totalDiskSize) * 100
+				logicos = append(logicos, logico{start: ebr.Ebr_start, celda: fmt.Sprintf("\t\t\t\t<TD>EBR (%d bytes)</TD>\n\t\t\t\t<TD>Lógica<br/>%s<br/>%.2f%% del disco</TD>\n", ebrSize, ebr.GetName(), logicalPercentage)})
+			}
+			for _, libre := range structures.GetLogicalFreeSpaces(part, cadena) {
+				freePercentage := float64(libre.Size) / float64(totalDiskSize) * 100
+				logicos = append(logicos, logico{start: libre.Start, celda: fmt.Sprintf("\t\t\t\t<TD>Libre<br/>%.2f%% del disco</TD>\n", freePercentage)})
 			}
+			sort.Slice(logicos, func(i, j int) bool {
+				return logicos[i].start < logicos[j].start
+			})
+			columnas := 0
+			for _, l := range logicos {
+				celdas = append(celdas, l.celda)
+				columnas += strings.Count(l.celda, "<TD>")
+			}
+			if columnas == 0 {
+				columnas = 1
+			}
+
+			content += "\t\t\t<TD>\n"
+			content += "\t\t\t\t<TABLE BORDER=\"0\" CELLBORDER=\"1\" CELLSPACING=\"0\">\n"
+			content += fmt.Sprintf("\t\t\t\t<TR><TD COLSPAN=\"%d\">Extendida<br/>%s<br/>%.2f%% del disco</TD></TR>\n", columnas, partName, percentage)
+			content += "\t\t\t\t<TR>\n"
+			content += strings.Join(celdas, "")
+			content += "\t\t\t\t</TR>\n"
+			content += "\t\t\t\t</TABLE>\n"
+			content += "\t\t\t</TD>\n"
+		} else { // Partición primaria
+			content += fmt.Sprintf("\t\t\t<TD>Primaria<br/>%s<br/>%.2f%% del disco</TD>\n", partName, percentage)
 		}
 	}
 
-	// Recalcular el espacio libre
-	freeSpace = totalDiskSize - usedSpace
-	freePercentage := float64(freeSpace) / float64(totalDiskSize) * 100
-
-	// Agregar el espacio libre restante
-	content += fmt.Sprintf("\t\t\t<TD>Libre<br/>%.2f%% del disco</TD>\n", freePercentage)
 	content += "\t\t\t</TR>\n"
 	content += "\t\t\t</TABLE>\n>];\n"
 	content += "\t}\n"
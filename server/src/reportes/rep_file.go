@@ -24,7 +24,7 @@ func ReporteFile(superblock *structures.SuperBlock, diskPath string, path string
 	// fmt.Println("Nombre archivo:", nombreArchivo)
 
 	//Aca ya se debe de obtner el archivo en el disco virtual con el -path
-	cade, err2 := superblock.GetFileContent(diskPath, parentDirs, nombreArchivo)
+	cade, err2 := superblock.GetFileContent(diskPath, parentDirs, nombreArchivo, structures.RootUID, structures.RootUID)
 	if err2 != nil {
 		return fmt.Errorf("error al obtener el archivo: %w", err2)
 	}
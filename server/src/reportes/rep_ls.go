@@ -37,7 +37,7 @@ func ReporteLs(superblock *structures.SuperBlock, diskPath string, path string,
 		`
 
 	//Se obtiene el codigo de tipo dot:
-	cade, err2 := superblock.ObtenerDotLS(diskPath, parentDirs, nombreArchivo)
+	cade, err2 := superblock.ObtenerDotLS(diskPath, parentDirs, nombreArchivo, structures.RootUID, structures.RootUID)
 	if err2 != nil {
 		return fmt.Errorf("error al obtener el archivo: %w", err2)
 	}
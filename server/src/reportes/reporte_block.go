@@ -26,82 +26,97 @@ func ReportBlock(superblock *structures.SuperBlock, diskPath string, path string
 	`
 	var uniones []string
 
+	// Obtener los inodos usados del bitmap, los inodos liberados no se muestran
+	usados, err := superblock.GetUsedInodes(diskPath)
+	if err != nil {
+		return err
+	}
+
 	// Iterar sobre cada inodo
-	for i := int32(0); i < superblock.S_inodes_count; i++ {
+	for _, i := range usados {
 		inode := &structures.Inode{}
 
 		// Deserializar el inodo
-		err := inode.Deserialize(diskPath, int64(superblock.S_inode_start+(i*superblock.S_inode_size)))
+		err := inode.Deserialize(diskPath, superblock.InodeOffset(i))
 		if err != nil {
 			return err
 		}
 
-		// Iterar sobre cada bloque del inodo (apuntadores)
-		for indice, blockIndex := range inode.I_block {
-			// Si el bloque no existe, salir
-			if blockIndex == -1 {
-				break
-			}
-			//TODO: validar los apuntadores 12,13,14 que esta en el indice del 13 al 15
-			//Bloque simple indirecto
-			if indice == 12 {
-
-				//Bloque doble indirecto
-			} else if indice == 13 {
-
-				//Bloque triple indirecto
-			} else if indice == 14 {
-
-				//Estos son los demas bloques
-			} else {
-				// Si el inodo es de tipo carpeta
-				if inode.I_type[0] == '0' {
-					block := &structures.FolderBlock{}
-					// Deserializar el bloque
-					err := block.Deserialize(diskPath, int64(superblock.S_block_start+(blockIndex*superblock.S_block_size))) // 64 porque es el tamaño de un bloque
-					if err != nil {
-						return err
-					}
-					//Aca se valida si el folderBlock esta ocupado
-					//Se recorre el contenido
-					if string(block.B_content[2].B_name[:]) != "-" && block.B_content[2].B_inodo != -1 {
-						//Se valida si el bloque existe
-						// Definir el contenido DOT para el inodo actual
-						dotContent += fmt.Sprintf(`bloque%d [label=<
-							<table border="0" cellborder="1" cellspacing="0">`, blockIndex)
-						//Aca esta el contenido
-						dotContent += fmt.Sprintf(`
-							<tr><td colspan="2" bgcolor="#0000FF"><font color="white"> REPORTE BLOQUE %d </font></td></tr>`, blockIndex)
-						//Obtinen el dot de un bloque de carpeta
-						dotContent += block.ObtenerDot()
-						//Aca se agrega el final del bloque
-						dotContent += "	</table>>];\n"
-						//Esta lista es para unir los bloques
-						uniones = append(uniones, fmt.Sprintf("bloque%d", blockIndex))
-					}
-
-					// Si el inodo es de tipo archivo
-				} else if inode.I_type[0] == '1' {
-					block := &structures.FileBlock{}
-					// Deserializar el bloque
-					err := block.Deserialize(diskPath, int64(superblock.S_block_start+(blockIndex*superblock.S_block_size))) // 64 porque es el tamaño de un bloque
-					if err != nil {
-						return err
-					}
+		// Bloques de datos del inodo, incluyendo los que estan en los bloques indirectos
+		bloques, err := superblock.GetInodeBlocks(diskPath, inode)
+		if err != nil {
+			return err
+		}
+		for _, blockIndex := range bloques {
+			// Si el inodo es de tipo carpeta
+			if inode.I_type[0] == '0' {
+				block := structures.NewFolderBlock(superblock.S_block_size)
+				// Deserializar el bloque
+				err := block.Deserialize(diskPath, int64(superblock.S_block_start+(blockIndex*superblock.S_block_size))) // S_block_size es el tamaño de un bloque
+				if err != nil {
+					return err
+				}
+				//Aca se valida si el folderBlock tiene alguna entrada ademas de . y ..
+				if len(block.Entries()) > 0 {
+					//Se valida si el bloque existe
 					// Definir el contenido DOT para el inodo actual
 					dotContent += fmt.Sprintf(`bloque%d [label=<
-					<table border="0" cellborder="1" cellspacing="0">`, blockIndex)
-					// Obtiene el bloque
+						<table border="0" cellborder="1" cellspacing="0">`, blockIndex)
+					//Aca esta el contenido
+					dotContent += fmt.Sprintf(`
+						<tr><td colspan="2" bgcolor="#0000FF"><font color="white"> REPORTE BLOQUE %d </font></td></tr>`, blockIndex)
+					//Obtinen el dot de un bloque de carpeta
 					dotContent += block.ObtenerDot()
 					//Aca se agrega el final del bloque
 					dotContent += "	</table>>];\n"
 					//Esta lista es para unir los bloques
 					uniones = append(uniones, fmt.Sprintf("bloque%d", blockIndex))
-					//continue
 				}
+
+				// Si el inodo es de tipo archivo
+			} else if inode.I_type[0] == '1' || inode.I_type[0] == '2' {
+				block := structures.NewFileBlock(superblock.S_block_size)
+				// Deserializar el bloque
+				err := block.Deserialize(diskPath, int64(superblock.S_block_start+(blockIndex*superblock.S_block_size))) // S_block_size es el tamaño de un bloque
+				if err != nil {
+					return err
+				}
+				// Definir el contenido DOT para el inodo actual
+				dotContent += fmt.Sprintf(`bloque%d [label=<
+				<table border="0" cellborder="1" cellspacing="0">`, blockIndex)
+				// Obtiene el bloque
+				dotContent += block.ObtenerDot()
+				//Aca se agrega el final del bloque
+				dotContent += "	</table>>];\n"
+				//Esta lista es para unir los bloques
+				uniones = append(uniones, fmt.Sprintf("bloque%d", blockIndex))
+				//continue
 			}
 			//Fin del i_bloque
 		}
+
+		// Bloques de apuntadores del simple, doble y triple indirecto
+		apuntadores, err := superblock.GetInodePointerBlocks(diskPath, inode)
+		if err != nil {
+			return err
+		}
+		for _, blockIndex := range apuntadores {
+			block := structures.NewPointerBlock(superblock.S_block_size)
+			// Deserializar el bloque
+			err := block.Deserialize(diskPath, superblock.BlockOffset(blockIndex))
+			if err != nil {
+				return err
+			}
+			// Definir el contenido DOT para el inodo actual
+			dotContent += fmt.Sprintf(`bloque%d [label=<
+			<table border="0" cellborder="1" cellspacing="0">`, blockIndex)
+			// Obtiene el bloque
+			dotContent += block.ObtenerDot()
+			//Aca se agrega el final del bloque
+			dotContent += "	</table>>];\n"
+			//Esta lista es para unir los bloques
+			uniones = append(uniones, fmt.Sprintf("bloque%d", blockIndex))
+		}
 		//Aca cambia de inodo por lo tanto no debe de estar enlazado
 	}
 
@@ -26,19 +26,25 @@ func ReportInode(superblock *structures.SuperBlock, diskPath string, path string
         node [shape=plaintext]
     `
 
+	// Obtener los inodos usados del bitmap, los inodos liberados no se muestran
+	usados, err := superblock.GetUsedInodes(diskPath)
+	if err != nil {
+		return err
+	}
+
 	// Iterar sobre cada inodo
-	for i := int32(0); i < superblock.S_inodes_count; i++ {
+	for indice, i := range usados {
 		inode := &structures.Inode{}
 		// Deserializar el inodo
-		err := inode.Deserialize(diskPath, int64(superblock.S_inode_start+(i*superblock.S_inode_size)))
+		err := inode.Deserialize(diskPath, superblock.InodeOffset(i))
 		if err != nil {
 			return err
 		}
 
 		// Convertir tiempos a string
-		atime := time.Unix(int64(inode.I_atime), 0).Format(time.RFC3339)
-		ctime := time.Unix(int64(inode.I_ctime), 0).Format(time.RFC3339)
-		mtime := time.Unix(int64(inode.I_mtime), 0).Format(time.RFC3339)
+		atime := time.Unix(inode.I_atime, 0).Format(time.RFC3339)
+		ctime := time.Unix(inode.I_ctime, 0).Format(time.RFC3339)
+		mtime := time.Unix(inode.I_mtime, 0).Format(time.RFC3339)
 
 		// Definir el contenido DOT para el inodo actual
 		dotContent += fmt.Sprintf(`inode%d [label=<
@@ -52,8 +58,9 @@ func ReportInode(superblock *structures.SuperBlock, diskPath string, path string
                 <tr><td>i_mtime</td><td>%s</td></tr>
                 <tr><td>i_type</td><td>%c</td></tr>
                 <tr><td>i_perm</td><td>%s</td></tr>
+                <tr><td>i_links</td><td>%d</td></tr>
                 <tr><td colspan="2" bgcolor="#0000FF"><font color="white"> BLOQUES DIRECTOS </font></td></tr>
-            `, i, i, inode.I_uid, inode.I_gid, inode.I_size, atime, ctime, mtime, rune(inode.I_type[0]), string(inode.I_perm[:]))
+            `, i, i, inode.I_uid, inode.I_gid, inode.I_size, atime, ctime, mtime, rune(inode.I_type[0]), string(inode.I_perm[:]), inode.I_links)
 
 		// Agregar los bloques directos a la tabla hasta el índice 11
 		for j, block := range inode.I_block {
@@ -75,8 +82,8 @@ func ReportInode(superblock *structures.SuperBlock, diskPath string, path string
         `, 13, inode.I_block[12], 14, inode.I_block[13], 15, inode.I_block[14])
 
 		// Agregar enlace al siguiente inodo si no es el último
-		if i < superblock.S_inodes_count-1 {
-			dotContent += fmt.Sprintf("inode%d -> inode%d;\n", i, i+1)
+		if indice < len(usados)-1 {
+			dotContent += fmt.Sprintf("inode%d -> inode%d;\n", i, usados[indice+1])
 		}
 	}
 
@@ -60,17 +60,16 @@ func ReportMBR(mbr *structures.MBR, path string, pathDisco string) error {
 
 		if partType == 'E' {
 			contador := 1
-			posicion := part.Part_start
-			var ebr structures.EBR
-			for {
-				err2 := ebr.DeserializeEBR(pathDisco, posicion)
-				if err2 != nil {
-					fmt.Println("Error deserializando el EBR:", err2)
-					return err2
-				}
-
-				if ebr.Ebr_next == -1 {
-					break
+			// Se obtienen todos los EBR de la extendida
+			cadena, err2 := structures.GetEBRChain(pathDisco, part.Part_start)
+			if err2 != nil {
+				fmt.Println("Error deserializando el EBR:", err2)
+				return err2
+			}
+			for _, ebr := range cadena {
+				// Los EBR vacios no tienen particion logica
+				if ebr.IsEmpty() {
+					continue
 				}
 
 				EBRmount := rune(ebr.Ebr_mount[0])
@@ -88,7 +87,6 @@ func ReportMBR(mbr *structures.MBR, path string, pathDisco string) error {
 				`, contador, EBRmount, EBRfit, ebr.Ebr_start, ebr.Ebr_size, ebr.Ebr_next, EBRname)
 
 				contador++
-				posicion = ebr.Ebr_next
 			}
 		}
 	}
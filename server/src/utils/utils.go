@@ -61,6 +61,35 @@ func GetLetterAndPartitionCorrelative(path string) (string, int, error) {
 	return pathToLetter[path], nextIndex, nil
 }
 
+// ZeroFillRange escribe ceros en el rango [start, start+size) del archivo indicado
+func ZeroFillRange(path string, start int64, size int64) error {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Mover el puntero al inicio del rango
+	_, err = file.Seek(start, 0)
+	if err != nil {
+		return err
+	}
+
+	// Escribir en el archivo usando un buffer de 1 MB
+	buffer := make([]byte, 1024*1024)
+	for size > 0 {
+		writeSize := int64(len(buffer))
+		if size < writeSize {
+			writeSize = size // Ajusta el tamaño de escritura si es menor que el buffer
+		}
+		if _, err := file.Write(buffer[:writeSize]); err != nil {
+			return err
+		}
+		size -= writeSize
+	}
+	return nil
+}
+
 // createParentDirs crea las carpetas padre si no existen
 func CreateParentDirs(path string) error {
 	dir := filepath.Dir(path)
@@ -88,6 +117,31 @@ func ResetMapsAndIndex() {
 	nextLetterIndex = 0                         // Reinicia el índice de la siguiente letra disponible
 }
 
+// Retorna una copia de las letras y correlativos asignados para poder guardarlos en disco
+func ExportLetterState() (map[string]string, map[string]int, int) {
+	letras := make(map[string]string)
+	for path, letra := range pathToLetter {
+		letras[path] = letra
+	}
+	correlativos := make(map[string]int)
+	for path, contador := range pathToPartitionCount {
+		correlativos[path] = contador
+	}
+	return letras, correlativos, nextLetterIndex
+}
+
+// Restaura las letras y correlativos guardados, asi los ids se mantienen entre reinicios
+func RestoreLetterState(letras map[string]string, correlativos map[string]int, siguiente int) {
+	ResetMapsAndIndex()
+	for path, letra := range letras {
+		pathToLetter[path] = letra
+	}
+	for path, contador := range correlativos {
+		pathToPartitionCount[path] = contador
+	}
+	nextLetterIndex = siguiente
+}
+
 // GetParentDirectories obtiene las carpetas padres y el directorio de destino
 func GetParentDirectories(path string) ([]string, string) {
 	// Normalizar el path